@@ -0,0 +1,38 @@
+package lru
+
+import "time"
+
+// LastAccess returns the time the entry for key was last read by Get or a
+// similar lookup, or written by Put, as measured by the cache's clock.
+// Peek does not count as an access. Returns false if the key is absent,
+// expired or negative.
+func (c *LRU[K, V]) LastAccess(key K) (time.Time, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	el, ok := c.idx[key]
+	if !ok {
+		return time.Time{}, false
+	}
+	kv := el.Value.(*entry[K, V])
+	if !c.live(kv) {
+		return time.Time{}, false
+	}
+	return kv.accessed, true
+}
+
+// KeysOlderThan returns the keys of live entries last accessed more than d
+// ago, ordered from least to most recently used. Unlike expiry this works
+// without a TTL, for example to find entries worth refreshing.
+func (c *LRU[K, V]) KeysOlderThan(d time.Duration) []K {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	cutoff := c.now().Add(-d)
+	var keys []K
+	for el := c.list.Back(); el != nil; el = el.Prev() {
+		kv := el.Value.(*entry[K, V])
+		if c.live(kv) && kv.accessed.Before(cutoff) {
+			keys = append(keys, kv.key)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,61 @@
+package lru
+
+import (
+	"testing"
+	"time"
+)
+
+// TestLastAccess verifies Get and Put record the access time and Peek does not.
+func TestLastAccess(t *testing.T) {
+	clock := newFakeClock()
+	cache, _ := NewLRUWithOptions(WithCapacity[int, string](2), WithClock[int, string](clock))
+	start := clock.Now()
+
+	cache.Put(1, "one")
+	if at, ok := cache.LastAccess(1); !ok || !at.Equal(start) {
+		t.Errorf("expected access at %v, got %v, %v", start, at, ok)
+	}
+
+	clock.Advance(time.Minute)
+	cache.Get(1)
+	if at, _ := cache.LastAccess(1); !at.Equal(start.Add(time.Minute)) {
+		t.Errorf("expected Get to record %v, got %v", start.Add(time.Minute), at)
+	}
+
+	clock.Advance(time.Minute)
+	cache.Peek(1)
+	if at, _ := cache.LastAccess(1); !at.Equal(start.Add(time.Minute)) {
+		t.Errorf("expected Peek not to record an access, got %v", at)
+	}
+
+	cache.Put(1, "uno")
+	if at, _ := cache.LastAccess(1); !at.Equal(start.Add(2 * time.Minute)) {
+		t.Errorf("expected overwrite to record %v, got %v", start.Add(2*time.Minute), at)
+	}
+
+	if _, ok := cache.LastAccess(2); ok {
+		t.Error("expected missing key to report false")
+	}
+}
+
+// TestKeysOlderThan verifies only entries not accessed recently are listed.
+func TestKeysOlderThan(t *testing.T) {
+	clock := newFakeClock()
+	cache, _ := NewLRUWithOptions(WithCapacity[int, string](5), WithClock[int, string](clock))
+	cache.Put(1, "one")
+	cache.Put(2, "two")
+	cache.Put(3, "three")
+
+	clock.Advance(10 * time.Minute)
+	cache.Get(2)
+	cache.Put(4, "four")
+	clock.Advance(time.Minute)
+
+	keys := cache.KeysOlderThan(5 * time.Minute)
+	if len(keys) != 2 || keys[0] != 1 || keys[1] != 3 {
+		t.Errorf("expected [1 3], got %v", keys)
+	}
+	if keys := cache.KeysOlderThan(time.Hour); len(keys) != 0 {
+		t.Errorf("expected no keys older than an hour, got %v", keys)
+	}
+}
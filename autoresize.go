@@ -0,0 +1,81 @@
+package lru
+
+// AutoResize configures automatic capacity growth. See WithAutoResize.
+type AutoResize struct {
+	// MaxCapacity bounds growth; the capacity never exceeds it.
+	MaxCapacity int
+	// Factor multiplies the capacity on each growth step. Values <= 1
+	// select DefaultAutoResizeFactor.
+	Factor float64
+	// MinHitRate is the fraction of lookups in a sample that must hit for
+	// the cache to grow. Values <= 0 select DefaultAutoResizeHitRate.
+	MinHitRate float64
+	// SampleSize is the number of lookups per sample. Values <= 0 select
+	// DefaultAutoResizeSampleSize.
+	SampleSize int
+}
+
+// Defaults for unset AutoResize fields.
+const (
+	DefaultAutoResizeFactor     = 2.0
+	DefaultAutoResizeHitRate    = 0.8
+	DefaultAutoResizeSampleSize = 1000
+)
+
+// autoResizer tracks lookups for automatic growth.
+type autoResizer struct {
+	cfg     AutoResize
+	lookups int // lookups in the current sample
+	hits    int // hits in the current sample
+}
+
+// WithAutoResize makes the cache grow its capacity automatically while it
+// is full and serving most lookups from cache, as sampled over every
+// cfg.SampleSize lookups, up to cfg.MaxCapacity. Growth only happens at
+// the end of a sample, never beyond MaxCapacity, and the capacity is never
+// shrunk automatically. NewLRUWithOptions returns an error if MaxCapacity
+// is less than the capacity or WithNoCapacityLimit is used.
+func WithAutoResize[K comparable, V any](cfg AutoResize) Option[K, V] {
+	return func(c *LRU[K, V]) {
+		if cfg.Factor <= 1 {
+			cfg.Factor = DefaultAutoResizeFactor
+		}
+		if cfg.MinHitRate <= 0 {
+			cfg.MinHitRate = DefaultAutoResizeHitRate
+		}
+		if cfg.SampleSize <= 0 {
+			cfg.SampleSize = DefaultAutoResizeSampleSize
+		}
+		c.resizer = &autoResizer{cfg: cfg}
+	}
+}
+
+// sampleLookup records a lookup for automatic growth and grows the cache
+// at the end of a sample if it is full with a high hit rate. Caller must
+// hold the write lock.
+func (c *LRU[K, V]) sampleLookup(hit bool) {
+	r := c.resizer
+	if r == nil {
+		return
+	}
+	r.lookups++
+	if hit {
+		r.hits++
+	}
+	if r.lookups < r.cfg.SampleSize {
+		return
+	}
+	rate := float64(r.hits) / float64(r.lookups)
+	r.lookups, r.hits = 0, 0
+	if c.list.Len() < c.cap || rate < r.cfg.MinHitRate || c.cap >= r.cfg.MaxCapacity {
+		return
+	}
+	grown := int(float64(c.cap) * r.cfg.Factor)
+	if grown <= c.cap {
+		grown = c.cap + 1
+	}
+	if grown > r.cfg.MaxCapacity {
+		grown = r.cfg.MaxCapacity
+	}
+	c.cap = grown
+}
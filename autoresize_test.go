@@ -0,0 +1,70 @@
+package lru
+
+import "testing"
+
+// TestAutoResize verifies a full cache with a high hit rate grows and stops
+// at MaxCapacity.
+func TestAutoResize(t *testing.T) {
+	cache, err := NewLRUWithOptions(
+		WithCapacity[int, int](4),
+		WithAutoResize[int, int](AutoResize{MaxCapacity: 10, SampleSize: 10}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 4; i++ {
+		cache.Put(i, i)
+	}
+
+	for i := 0; i < 10; i++ {
+		cache.Get(i % 4)
+	}
+	if cache.Cap() != 8 {
+		t.Errorf("expected capacity to double to 8, got %d", cache.Cap())
+	}
+
+	for i := 0; i < 10; i++ {
+		cache.Get(0)
+	}
+	if cache.Cap() != 8 {
+		t.Errorf("expected no growth while not full, got %d", cache.Cap())
+	}
+
+	for i := 4; i < 8; i++ {
+		cache.Put(i, i)
+	}
+	for i := 0; i < 20; i++ {
+		cache.Get(i % 8)
+	}
+	if cache.Cap() != 10 {
+		t.Errorf("expected capacity to stop at 10, got %d", cache.Cap())
+	}
+}
+
+// TestAutoResizeLowHitRate verifies a full cache that mostly misses does
+// not grow.
+func TestAutoResizeLowHitRate(t *testing.T) {
+	cache, _ := NewLRUWithOptions(
+		WithCapacity[int, int](2),
+		WithAutoResize[int, int](AutoResize{MaxCapacity: 10, SampleSize: 10}),
+	)
+	cache.Put(0, 0)
+	cache.Put(1, 1)
+	for i := 0; i < 10; i++ {
+		cache.Get(i)
+	}
+	if cache.Cap() != 2 {
+		t.Errorf("expected capacity to stay 2, got %d", cache.Cap())
+	}
+}
+
+// TestAutoResizeInvalid verifies MaxCapacity must cover the capacity.
+func TestAutoResizeInvalid(t *testing.T) {
+	_, err := NewLRUWithOptions(
+		WithCapacity[int, int](4),
+		WithAutoResize[int, int](AutoResize{MaxCapacity: 2}),
+	)
+	if err == nil {
+		t.Error("expected error for MaxCapacity below capacity")
+	}
+}
@@ -0,0 +1,163 @@
+package lru
+
+import "strings"
+
+// GetMany looks up keys under a single lock acquisition and returns the
+// entries that were found. Every found key is promoted as if by Get, in the
+// order given, so the last found key ends up most recently used.
+func (c *LRU[K, V]) GetMany(keys []K) map[K]V {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	found := make(map[K]V, len(keys))
+	for _, key := range keys {
+		if val, ok := c.get(key); ok {
+			found[key] = val
+		}
+	}
+	return found
+}
+
+// Lookup is the result of looking up one key with GetOrdered.
+type Lookup[K comparable, V any] struct {
+	Key   K
+	Value V
+	Found bool
+}
+
+// GetOrdered looks up keys under a single lock acquisition like GetMany,
+// but returns one result per key in the order given, including misses and
+// repeated keys. Found keys are promoted as if by Get.
+func (c *LRU[K, V]) GetOrdered(keys []K) []Lookup[K, V] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	results := make([]Lookup[K, V], len(keys))
+	for i, key := range keys {
+		val, ok := c.get(key)
+		results[i] = Lookup[K, V]{Key: key, Value: val, Found: ok}
+	}
+	return results
+}
+
+// PutMany inserts or updates all items under a single lock acquisition,
+// evicting as needed after each insert. Items are inserted in map iteration
+// order, which is unspecified, so if there are more items than the capacity
+// which of them survive is unspecified too. Use WarmUp for a defined order.
+func (c *LRU[K, V]) PutMany(items map[K]V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, val := range items {
+		c.put(key, val, c.ttl, c.sizeOf(key, val))
+	}
+}
+
+// RemoveMany deletes the entries for keys under a single lock acquisition
+// and returns the number actually removed. The eviction callback is not
+// called.
+func (c *LRU[K, V]) RemoveMany(keys []K) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n := 0
+	for _, key := range keys {
+		if el, ok := c.idx[key]; ok {
+			kv := c.removeElement(el)
+			c.notifyRemove(kv.key, kv.val, Manual)
+			n++
+		}
+	}
+	return n
+}
+
+// Filter removes every entry for which keep returns false and returns the
+// number removed. The eviction callback is called for each removed entry,
+// but removals are not counted as evictions in Stats. Entries are visited
+// from least to most recently used; expired entries are expired instead of
+// being passed to keep, and negative entries stored with PutNegative are
+// kept without being passed to keep. The write lock is held throughout, so
+// keep must not call back into the cache.
+func (c *LRU[K, V]) Filter(keep func(key K, value V) bool) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.filter(func(kv *entry[K, V]) bool {
+		return !kv.negative && !keep(kv.key, kv.val)
+	})
+}
+
+// filter implements Filter, removing the live and negative entries for
+// which remove returns true. Caller must hold the write lock.
+func (c *LRU[K, V]) filter(remove func(kv *entry[K, V]) bool) int {
+	n := 0
+	for el := c.list.Back(); el != nil; {
+		prev := el.Prev()
+		kv := el.Value.(*entry[K, V])
+		switch {
+		case c.expired(kv):
+			c.expire(el)
+		case remove(kv):
+			c.removeElement(el)
+			c.notifyEvict(kv.key, kv.val)
+			c.notifyRemove(kv.key, kv.val, Manual)
+			n++
+		}
+		el = prev
+	}
+	return n
+}
+
+// UpdateAll replaces the value of every live entry with the result of f,
+// without changing recency or expiry, and calls the update callback for
+// each. Negative entries stored with PutNegative are left as they are.
+// Costs are recomputed with the sizer; an entry whose new value alone
+// exceeds the cost budget is removed, and least recently used entries are
+// evicted afterwards if the cache no longer fits its cost budget. The write
+// lock is held throughout, so f must not call back into the cache.
+func (c *LRU[K, V]) UpdateAll(f func(key K, value V) V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for el := c.list.Back(); el != nil; {
+		prev := el.Prev()
+		kv := el.Value.(*entry[K, V])
+		if c.expired(kv) {
+			c.expire(el)
+			el = prev
+			continue
+		}
+		if kv.negative {
+			el = prev
+			continue
+		}
+		val := f(kv.key, kv.val)
+		if c.sizer != nil {
+			cost := c.sizer(kv.key, val)
+			if c.tooLarge(cost) {
+				c.removeElement(el)
+				c.notifyRemove(kv.key, kv.val, Replaced)
+				el = prev
+				continue
+			}
+			c.cost += cost - kv.cost
+			kv.cost = cost
+		}
+		old := kv.val
+		c.setValue(kv, val)
+		if c.onUpdate != nil {
+			c.onUpdate(kv.key, val)
+		}
+		c.publish(EventUpdate, kv.key, val)
+		c.notifyRemove(kv.key, old, Replaced)
+		el = prev
+	}
+	c.evictOverflow(nil)
+}
+
+// InvalidatePrefix removes every entry of c whose key starts with prefix and
+// returns the number removed, calling the eviction callback for each like
+// Filter. Negative entries with the prefix are removed too. It is a
+// function rather than a method because it only applies to caches with
+// string keys.
+func InvalidatePrefix[K ~string, V any](c *LRU[K, V], prefix string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.filter(func(kv *entry[K, V]) bool {
+		return strings.HasPrefix(string(kv.key), prefix)
+	})
+}
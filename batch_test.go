@@ -0,0 +1,198 @@
+package lru
+
+import "testing"
+
+// TestGetMany verifies partial hits and promotion of found keys.
+func TestGetMany(t *testing.T) {
+	cache, _ := NewLRU[int, string](3)
+	cache.Put(1, "one")
+	cache.Put(2, "two")
+	cache.Put(3, "three")
+
+	got := cache.GetMany([]int{1, 4, 2})
+	if len(got) != 2 || got[1] != "one" || got[2] != "two" {
+		t.Errorf("expected {1:one 2:two}, got %v", got)
+	}
+
+	cache.Put(5, "five") // 3 was not promoted
+	if cache.Contains(3) {
+		t.Errorf("expected key 3 to be evicted")
+	}
+	if s := cache.Stats(); s.Hits != 2 || s.Misses != 1 {
+		t.Errorf("expected 2 hits and 1 miss, got %+v", s)
+	}
+}
+
+// TestGetOrdered verifies results follow the input order, including misses
+// and duplicate keys.
+func TestGetOrdered(t *testing.T) {
+	cache, _ := NewLRU[int, string](3)
+	cache.Put(1, "one")
+	cache.Put(2, "two")
+	cache.Put(3, "three")
+
+	got := cache.GetOrdered([]int{2, 4, 1, 2, 5})
+	want := []Lookup[int, string]{
+		{Key: 2, Value: "two", Found: true},
+		{Key: 4},
+		{Key: 1, Value: "one", Found: true},
+		{Key: 2, Value: "two", Found: true},
+		{Key: 5},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d results, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("result %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+
+	cache.Put(6, "six") // 3 was not promoted
+	if cache.Contains(3) {
+		t.Errorf("expected key 3 to be evicted")
+	}
+	if got := cache.GetOrdered(nil); len(got) != 0 {
+		t.Errorf("expected no results, got %v", got)
+	}
+}
+
+// TestPutMany verifies all items are stored and older entries evicted.
+func TestPutMany(t *testing.T) {
+	cache, _ := NewLRU[int, string](3)
+	cache.Put(1, "one")
+	cache.Put(2, "two")
+
+	cache.PutMany(map[int]string{3: "three", 4: "four"})
+
+	if cache.Contains(1) {
+		t.Errorf("expected key 1 to be evicted")
+	}
+	for _, k := range []int{2, 3, 4} {
+		if !cache.Contains(k) {
+			t.Errorf("expected key %d to be present", k)
+		}
+	}
+	if keys := cache.Keys(); keys[0] != 2 {
+		t.Errorf("expected key 2 to be least recently used, got %v", keys)
+	}
+}
+
+// TestPutManyOverCapacity verifies inserting twice the capacity at once
+// leaves a full cache holding only new items.
+func TestPutManyOverCapacity(t *testing.T) {
+	cache, _ := NewLRU[int, int](3)
+	cache.Put(0, 0)
+	items := make(map[int]int)
+	for i := 1; i <= 6; i++ {
+		items[i] = i
+	}
+
+	cache.PutMany(items)
+	if cache.Len() != 3 || cache.Contains(0) {
+		t.Errorf("expected 3 new items, got %v", cache.Keys())
+	}
+	for _, k := range cache.Keys() {
+		if _, ok := items[k]; !ok {
+			t.Errorf("unexpected key %d", k)
+		}
+	}
+}
+
+// TestRemoveMany verifies the removed count with present and absent keys.
+func TestRemoveMany(t *testing.T) {
+	cache, _ := NewLRU[int, string](4)
+	cache.Put(1, "one")
+	cache.Put(2, "two")
+	cache.Put(3, "three")
+
+	if n := cache.RemoveMany([]int{1, 3, 5, 1}); n != 2 {
+		t.Errorf("expected 2 removed, got %d", n)
+	}
+	if cache.Len() != 1 || !cache.Contains(2) {
+		t.Errorf("expected only key 2 to remain, got %v", cache.Keys())
+	}
+}
+
+// TestFilter verifies entries failing the predicate are removed with the
+// eviction callback and the rest keep their order.
+func TestFilter(t *testing.T) {
+	cache, _ := NewLRU[string, int](5)
+	var evicted []string
+	cache.SetEvictionCallback(func(k string, v int) { evicted = append(evicted, k) })
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+	cache.Put("d", 4)
+	cache.Put("e", 5)
+
+	n := cache.Filter(func(k string, v int) bool { return v%2 == 1 })
+	if n != 2 {
+		t.Errorf("expected 2 removed, got %d", n)
+	}
+	if len(evicted) != 2 || evicted[0] != "b" || evicted[1] != "d" {
+		t.Errorf("expected callbacks for [b d], got %v", evicted)
+	}
+	keys := cache.Keys()
+	if len(keys) != 3 || keys[0] != "a" || keys[1] != "c" || keys[2] != "e" {
+		t.Errorf("expected [a c e], got %v", keys)
+	}
+	if cache.EvictionCount() != 0 {
+		t.Errorf("expected filtered entries not counted as evictions, got %d", cache.EvictionCount())
+	}
+
+	if n := cache.Filter(func(string, int) bool { return true }); n != 0 {
+		t.Errorf("expected nothing removed, got %d", n)
+	}
+}
+
+// TestUpdateAll verifies every value is replaced and the order is kept.
+func TestUpdateAll(t *testing.T) {
+	cache, _ := NewLRU[string, int](3)
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+	cache.Get("a")
+	before := cache.Keys()
+
+	cache.UpdateAll(func(k string, v int) int { return v + 10 })
+
+	after := cache.Keys()
+	for i := range before {
+		if before[i] != after[i] {
+			t.Fatalf("expected order %v to be kept, got %v", before, after)
+		}
+	}
+	for k, want := range map[string]int{"a": 11, "b": 12, "c": 13} {
+		if v, _ := cache.Peek(k); v != want {
+			t.Errorf("expected %s=%d, got %d", k, want, v)
+		}
+	}
+}
+
+// TestInvalidatePrefix verifies only keys under the prefix are removed.
+func TestInvalidatePrefix(t *testing.T) {
+	cache, _ := NewLRU[string, int](10)
+	var evicted []string
+	cache.SetEvictionCallback(func(k string, v int) { evicted = append(evicted, k) })
+	cache.Put("user:1:profile", 1)
+	cache.Put("user:1:settings", 2)
+	cache.Put("user:12:profile", 3)
+	cache.Put("user:2:profile", 4)
+	cache.Put("group:1", 5)
+
+	if n := InvalidatePrefix(cache, "user:1:"); n != 2 {
+		t.Errorf("expected 2 removed, got %d", n)
+	}
+	keys := cache.Keys()
+	if len(keys) != 3 || keys[0] != "user:12:profile" || keys[1] != "user:2:profile" || keys[2] != "group:1" {
+		t.Errorf("expected [user:12:profile user:2:profile group:1], got %v", keys)
+	}
+	if len(evicted) != 2 {
+		t.Errorf("expected 2 eviction callbacks, got %v", evicted)
+	}
+
+	if n := InvalidatePrefix(cache, "user:"); n != 2 || cache.Len() != 1 {
+		t.Errorf("expected 2 more removed leaving 1, got %d leaving %d", n, cache.Len())
+	}
+}
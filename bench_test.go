@@ -0,0 +1,129 @@
+package lru
+
+import "testing"
+
+// benchSize is the number of entries seeded before each benchmark.
+const benchSize = 10000
+
+// newBenchCache returns a full cache holding keys [0, benchSize).
+func newBenchCache(b *testing.B) *LRU[int, int] {
+	b.Helper()
+	cache, err := NewLRU[int, int](benchSize)
+	if err != nil {
+		b.Fatal(err)
+	}
+	for i := 0; i < benchSize; i++ {
+		cache.Put(i, i)
+	}
+	return cache
+}
+
+// BenchmarkGetHit measures Get on keys that are present.
+func BenchmarkGetHit(b *testing.B) {
+	cache := newBenchCache(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			cache.Get(i % benchSize)
+			i++
+		}
+	})
+}
+
+// BenchmarkGetMiss measures Get on keys that are absent.
+func BenchmarkGetMiss(b *testing.B) {
+	cache := newBenchCache(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			cache.Get(benchSize + i)
+			i++
+		}
+	})
+}
+
+// BenchmarkPutNew measures inserting new keys into a cache with spare room.
+func BenchmarkPutNew(b *testing.B) {
+	cache, _ := NewLRU[int, int](b.N + 1)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cache.Put(i, i)
+	}
+}
+
+// BenchmarkPutOverwrite measures updating keys that are present.
+func BenchmarkPutOverwrite(b *testing.B) {
+	cache := newBenchCache(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			cache.Put(i%benchSize, i)
+			i++
+		}
+	})
+}
+
+// BenchmarkPutWithEviction measures inserting into a full cache, so every
+// Put evicts the least recently used entry.
+func BenchmarkPutWithEviction(b *testing.B) {
+	cache := newBenchCache(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cache.Put(benchSize+i, i)
+	}
+}
+
+// BenchmarkPutWithEvictionBatch measures inserting into a full cache that
+// evicts in batches of 100.
+func BenchmarkPutWithEvictionBatch(b *testing.B) {
+	cache, err := NewLRUWithOptions(WithCapacity[int, int](benchSize), WithEvictionBatch[int, int](100))
+	if err != nil {
+		b.Fatal(err)
+	}
+	for i := 0; i < benchSize; i++ {
+		cache.Put(i, i)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cache.Put(benchSize+i, i)
+	}
+}
+
+// benchmarkWarmUp measures filling a cache with no item limit, built with
+// the given extra options, with benchSize entries.
+func benchmarkWarmUp(b *testing.B, opts ...Option[int, int]) {
+	opts = append([]Option[int, int]{
+		WithNoCapacityLimit[int, int](),
+		WithMaxCost(benchSize, func(k, v int) int64 { return 1 }),
+	}, opts...)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		cache, err := NewLRUWithOptions(opts...)
+		if err != nil {
+			b.Fatal(err)
+		}
+		for j := 0; j < benchSize; j++ {
+			cache.Put(j, j)
+		}
+	}
+}
+
+// BenchmarkWarmUp measures filling a cache whose index starts small.
+func BenchmarkWarmUp(b *testing.B) {
+	benchmarkWarmUp(b)
+}
+
+// BenchmarkWarmUpInitialSize measures filling a cache whose index is
+// preallocated with WithInitialSize.
+func BenchmarkWarmUpInitialSize(b *testing.B) {
+	benchmarkWarmUp(b, WithInitialSize[int, int](benchSize))
+}
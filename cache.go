@@ -0,0 +1,21 @@
+package lru
+
+// Cache is the common interface of the caches in this package, for code
+// that should not depend on a concrete cache type.
+type Cache[K comparable, V any] interface {
+	// Get retrieves the value for key if present, counting as an access.
+	Get(key K) (V, bool)
+	// Put inserts or updates the value for key.
+	Put(key K, val V)
+	// Remove deletes the entry for key and reports whether it was present.
+	Remove(key K) bool
+	// Len returns the number of entries.
+	Len() int
+	// Contains reports whether key is present, without counting as an access.
+	Contains(key K) bool
+}
+
+var (
+	_ Cache[int, string] = (*LRU[int, string])(nil)
+	_ Cache[int, string] = (*ShardedLRU[int, string])(nil)
+)
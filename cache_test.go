@@ -0,0 +1,31 @@
+package lru
+
+import "testing"
+
+// exerciseCache runs basic operations through the Cache interface.
+func exerciseCache(t *testing.T, c Cache[int, string]) {
+	t.Helper()
+	c.Put(1, "one")
+	c.Put(2, "two")
+	if v, ok := c.Get(1); !ok || v != "one" {
+		t.Errorf("expected one, got %q, %v", v, ok)
+	}
+	if !c.Contains(2) || c.Contains(3) {
+		t.Error("expected Contains to report 2 but not 3")
+	}
+	if !c.Remove(2) || c.Remove(2) {
+		t.Error("expected Remove to succeed once")
+	}
+	if c.Len() != 1 {
+		t.Errorf("expected len 1, got %d", c.Len())
+	}
+}
+
+// TestCacheInterface verifies the caches work through the Cache interface.
+func TestCacheInterface(t *testing.T) {
+	cache, _ := NewLRU[int, string](4)
+	exerciseCache(t, cache)
+
+	sharded, _ := NewShardedLRU[int, string](2, 4)
+	exerciseCache(t, sharded)
+}
@@ -0,0 +1,13 @@
+package lru
+
+import "time"
+
+// Clock tells the time used to compute and check entry expiry.
+type Clock interface {
+	Now() time.Time
+}
+
+// realClock is a Clock backed by time.Now.
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
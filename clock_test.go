@@ -0,0 +1,63 @@
+package lru
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced clock for deterministic TTL tests.
+type fakeClock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.t
+}
+
+func (f *fakeClock) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.t = f.t.Add(d)
+}
+
+// TestClockDeadline verifies expiry exactly at, just before and just after
+// the deadline.
+func TestClockDeadline(t *testing.T) {
+	clock := newFakeClock()
+	cache, err := NewLRUWithOptions(WithCapacity[int, string](2), WithClock[int, string](clock))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cache.PutWithTTL(1, "one", time.Minute)
+
+	clock.Advance(time.Minute - time.Nanosecond)
+	if !cache.Contains(1) {
+		t.Errorf("expected entry to be live just before the deadline")
+	}
+	clock.Advance(time.Nanosecond)
+	if cache.Contains(1) {
+		t.Errorf("expected entry to be expired at the deadline")
+	}
+	clock.Advance(time.Nanosecond)
+	if _, ok := cache.Get(1); ok {
+		t.Errorf("expected entry to be expired just after the deadline")
+	}
+}
+
+// TestDefaultClock verifies caches use the real clock by default.
+func TestDefaultClock(t *testing.T) {
+	cache, _ := NewLRU[int, string](1)
+
+	if _, ok := cache.clock.(realClock); !ok {
+		t.Errorf("expected realClock by default, got %T", cache.clock)
+	}
+}
@@ -0,0 +1,75 @@
+package lru
+
+import (
+	"container/list"
+	"fmt"
+)
+
+// Clone returns an independent copy of the cache with the same capacity,
+// limits, default TTL, clock, refresh-ahead loader, value index, overflow
+// handler, before-evict hook and auto-resize settings, holding the same live
+// entries in the same recency order with the same expiry times and
+// insertion sequence numbers.
+//
+// Callbacks that observe the cache, such as the eviction and expiration
+// callbacks, are not copied, nor are statistics, subscribers or a running
+// janitor; set them on the clone if needed. The refresh-ahead loader,
+// overflow handler and before-evict hook are copied because they decide
+// what the cache holds, so without them the clone would behave differently.
+// The clone shares them with the original, so they must be safe to call
+// from both.
+//
+// The eviction policy is copied with its state through PolicyCloner, which
+// every policy in this package implements. Clone returns an error wrapping
+// ErrPolicyNotCloneable if a custom policy does not implement it, rather
+// than giving the clone a different eviction order.
+func (c *LRU[K, V]) Clone() (*LRU[K, V], error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	cloner, ok := c.policy.(PolicyCloner)
+	if !ok {
+		return nil, fmt.Errorf("lru: clone policy %T: %w", c.policy, ErrPolicyNotCloneable)
+	}
+	clone := &LRU[K, V]{
+		cap:       c.cap,
+		list:      list.New(),
+		idx:       make(map[K]*list.Element, c.sizeHint()),
+		ttl:       c.ttl,
+		maxCost:   c.maxCost,
+		sizer:     c.sizer,
+		seq:       c.seq,
+		clock:     c.clock,
+		refreshAt: c.refreshAt,
+		refresher: c.refresher,
+
+		onOverflow:  c.onOverflow,
+		expireFirst: c.expireFirst,
+		initSize:    c.initSize,
+		peekTTL:     c.peekTTL,
+		beforeEvict: c.beforeEvict,
+		copier:      c.copier,
+		evictBatch:  c.evictBatch,
+		noPromote:   c.noPromote,
+	}
+	if c.resizer != nil {
+		clone.resizer = &autoResizer{cfg: c.resizer.cfg}
+	}
+	if c.values != nil {
+		clone.values = make(map[any]map[K]struct{})
+	}
+	elems := make(map[*list.Element]*list.Element, c.list.Len())
+	for el := c.list.Back(); el != nil; el = el.Prev() {
+		kv := el.Value.(*entry[K, V])
+		if c.expired(kv) {
+			continue
+		}
+		cp := *kv
+		nel := clone.list.PushFront(&cp)
+		clone.idx[cp.key] = nel
+		clone.cost += cp.cost
+		clone.indexValue(cp.key, cp.val)
+		elems[el] = nel
+	}
+	clone.policy = cloner.ClonePolicy(elems)
+	return clone, nil
+}
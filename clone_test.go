@@ -0,0 +1,216 @@
+package lru
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// TestClone verifies the clone holds the same entries in the same order.
+func TestClone(t *testing.T) {
+	cache, _ := NewLRU[string, int](3)
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+	cache.Get("a")
+
+	clone, _ := cache.Clone()
+	if clone.Cap() != 3 {
+		t.Errorf("expected capacity 3, got %d", clone.Cap())
+	}
+	keys := clone.Keys()
+	if len(keys) != 3 || keys[0] != "b" || keys[1] != "c" || keys[2] != "a" {
+		t.Errorf("expected order [b c a], got %v", keys)
+	}
+
+	clone.Put("d", 4)
+	if clone.Contains("b") {
+		t.Error("expected clone to evict its least recently used entry")
+	}
+}
+
+// TestCloneIndependent verifies changes to either cache do not affect the other.
+func TestCloneIndependent(t *testing.T) {
+	cache, _ := NewLRU[string, int](3)
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+
+	clone, _ := cache.Clone()
+	clone.Remove("a")
+	if v, ok := cache.Get("a"); !ok || v != 1 {
+		t.Errorf("expected original to keep a=1, got %d, %v", v, ok)
+	}
+
+	cache.Remove("b")
+	cache.Put("c", 3)
+	if v, ok := clone.Get("b"); !ok || v != 2 {
+		t.Errorf("expected clone to keep b=2, got %d, %v", v, ok)
+	}
+	if clone.Contains("c") {
+		t.Error("expected clone not to see later puts to the original")
+	}
+}
+
+// TestCloneTTL verifies the clone keeps expiry times but not callbacks.
+func TestCloneTTL(t *testing.T) {
+	clock := newFakeClock()
+	evicted := 0
+	cache, _ := NewLRUWithOptions(
+		WithCapacity[int, string](1),
+		WithClock[int, string](clock),
+		WithEvictionCallback(func(int, string) { evicted++ }),
+	)
+	cache.PutWithTTL(1, "one", time.Second)
+
+	clone, _ := cache.Clone()
+	clone.Put(2, "two")
+	if evicted != 0 {
+		t.Errorf("expected clone not to share the eviction callback, got %d calls", evicted)
+	}
+
+	clone.PutWithTTL(1, "one", time.Second)
+	clock.Advance(2 * time.Second)
+	if _, ok := cache.Get(1); ok {
+		t.Error("expected original entry to expire")
+	}
+	clone2, _ := cache.Clone()
+	if clone2.Len() != 0 {
+		t.Errorf("expected expired entries not to be cloned, got %d", clone2.Len())
+	}
+}
+
+// TestCloneStatefulPolicy verifies a clone of an LFU cache gets its own policy.
+func TestCloneStatefulPolicy(t *testing.T) {
+	cache, _ := NewLRUWithOptions(WithCapacity[int, int](2), WithLFU[int, int]())
+	cache.Put(1, 1)
+	cache.Put(2, 2)
+
+	clone, _ := cache.Clone()
+	clone.Put(3, 3)
+	cache.Put(4, 4)
+	if clone.Len() != 2 || cache.Len() != 2 {
+		t.Errorf("expected both caches to stay at capacity, got %d and %d", clone.Len(), cache.Len())
+	}
+	if !clone.Contains(3) || clone.Contains(4) {
+		t.Errorf("expected clone to hold its own puts, got %v", clone.Keys())
+	}
+}
+
+// TestCloneLFUState verifies a clone of an LFU cache keeps the access
+// frequencies, so it evicts the same entry as the original.
+func TestCloneLFUState(t *testing.T) {
+	cache, _ := NewLRUWithOptions(WithCapacity[int, int](2), WithLFU[int, int]())
+	cache.Put(1, 1)
+	cache.Put(2, 2)
+	cache.Get(1)
+	cache.Get(1)
+
+	clone, _ := cache.Clone()
+	cache.Put(3, 3)
+	clone.Put(3, 3)
+	for _, c := range []*LRU[int, int]{cache, clone} {
+		if !c.Contains(1) || c.Contains(2) {
+			t.Errorf("expected the cold key 2 to be evicted, got %v", c.Keys())
+		}
+	}
+}
+
+// TestCloneTwoQueueState verifies a clone of a 2Q cache keeps its queues
+// and ghost keys.
+func TestCloneTwoQueueState(t *testing.T) {
+	cache, _ := NewLRUWithOptions(
+		WithCapacity[int, int](4),
+		WithPolicy[int, int](NewTwoQueuePolicy[int, int](4, 0.5, 1)),
+	)
+	for i := 1; i <= 5; i++ {
+		cache.Put(i, i) // 1 is evicted from A1in into the ghost queue
+	}
+
+	clone, _ := cache.Clone()
+	for _, c := range []*LRU[int, int]{cache, clone} {
+		c.Put(1, 1) // a ghost hit goes straight to Am
+		for i := 6; i <= 9; i++ {
+			c.Put(i, i) // a scan LRUPolicy would evict 1 with
+		}
+		if !c.Contains(1) {
+			t.Errorf("expected hot key 1 to survive a scan, got %v", c.Keys())
+		}
+	}
+	if fmt.Sprint(clone.Keys()) != fmt.Sprint(cache.Keys()) {
+		t.Errorf("expected the clone to match, got %v and %v", clone.Keys(), cache.Keys())
+	}
+}
+
+// TestCloneUncloneablePolicy verifies Clone refuses a policy it cannot copy.
+func TestCloneUncloneablePolicy(t *testing.T) {
+	custom := struct{ Policy }{LRUPolicy{}} // hides ClonePolicy
+	cache, _ := NewLRUWithOptions(WithCapacity[int, int](2), WithPolicy[int, int](custom))
+	if clone, err := cache.Clone(); !errors.Is(err, ErrPolicyNotCloneable) || clone != nil {
+		t.Errorf("expected ErrPolicyNotCloneable, got %v", err)
+	}
+}
+
+// TestCloneOverflowHandler verifies a clone keeps rejecting new keys when
+// full, like the original.
+func TestCloneOverflowHandler(t *testing.T) {
+	cache, _ := NewLRUWithOptions(
+		WithCapacity[int, int](1),
+		WithOverflowHandler(func(key, value, victim int) (int, bool) { return 0, false }),
+	)
+	cache.Put(1, 1)
+
+	clone, _ := cache.Clone()
+	if err := clone.PutChecked(2, 2); !errors.Is(err, ErrCacheFull) {
+		t.Errorf("expected ErrCacheFull, got %v", err)
+	}
+	if !clone.Contains(1) {
+		t.Errorf("expected 1 to be kept, got %v", clone.Keys())
+	}
+}
+
+// TestCloneBeforeEvict verifies a clone keeps the before-evict hook, so it
+// still refuses to evict entries the hook fails for.
+func TestCloneBeforeEvict(t *testing.T) {
+	cache, _ := NewLRUWithOptions(
+		WithCapacity[int, int](1),
+		WithBeforeEvict(func(key, value int) error { return errors.New("not written back") }),
+	)
+	cache.Put(1, 1)
+
+	clone, _ := cache.Clone()
+	clone.Put(2, 2)
+	if !clone.Contains(1) {
+		t.Errorf("expected the hook to keep 1, got %v", clone.Keys())
+	}
+}
+
+// TestCloneRefreshAhead verifies a clone shares the refresh-ahead loader, so
+// its entries are refreshed like the original's.
+func TestCloneRefreshAhead(t *testing.T) {
+	clock := newFakeClock()
+	cache, _ := NewLRUWithOptions(
+		WithCapacity[int, string](2),
+		WithDefaultTTL[int, string](10*time.Second),
+		WithClock[int, string](clock),
+		WithRefreshAhead(0.5, func(k int) (string, error) { return "new", nil }),
+	)
+	cache.Put(1, "old")
+
+	clone, _ := cache.Clone()
+	clock.Advance(6 * time.Second)
+	clone.Get(1)
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if val, _ := clone.Peek(1); val == "new" {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if val, _ := clone.Peek(1); val != "new" {
+		t.Errorf("expected the clone to refresh its entry, got %v", val)
+	}
+	if val, _ := cache.Peek(1); val != "old" {
+		t.Errorf("expected the original to keep its value, got %v", val)
+	}
+}
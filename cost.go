@@ -0,0 +1,83 @@
+package lru
+
+import "errors"
+
+// NewLRUWithMaxCost creates a new LRU cache bounded by both capacity items
+// and a total cost of maxCost, as measured by sizer. Least recently used
+// entries are evicted until both limits are met. An entry whose cost alone
+// exceeds maxCost is not stored. The sizer may be nil if costs are only
+// supplied through PutWithCost, in which case Put stores entries at zero cost.
+// Returns an error if capacity <= 0 or maxCost <= 0.
+//
+// Overwriting a key, whether by Put, UpdateValue or a refresh, replaces its
+// cost. If the total then exceeds maxCost, least recently used entries other
+// than the one written are evicted until it fits. If the new value alone
+// exceeds maxCost, the entry is removed as if it had never fit.
+func NewLRUWithMaxCost[K comparable, V any](capacity int, maxCost int64, sizer func(key K, value V) int64) (*LRU[K, V], error) {
+	if maxCost <= 0 {
+		return nil, errors.New("max cost must be greater than 0")
+	}
+	return NewLRUWithOptions(WithCapacity[K, V](capacity), WithMaxCost(maxCost, sizer))
+}
+
+// PutWithCost inserts or updates the value for the given key with an explicit
+// cost, bypassing the sizer. Overwriting a key replaces its previous cost.
+// Least recently used items are evicted until the cache fits its limits.
+func (c *LRU[K, V]) PutWithCost(key K, val V, cost int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.put(key, val, c.ttl, cost)
+}
+
+// PutChecked inserts or updates the value for the given key like Put, but
+// returns ErrItemTooLarge instead of storing an entry whose cost alone
+// exceeds the cost budget. Other entries are left untouched; any existing
+// entry for the key is removed so a stale value is never served. It returns
+// ErrCacheFull if the overflow handler rejected the entry.
+func (c *LRU[K, V]) PutChecked(key K, val V) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, err := c.put(key, val, c.ttl, c.sizeOf(key, val))
+	return err
+}
+
+// PutWithCostChecked is like PutWithCost but returns ErrItemTooLarge if
+// cost exceeds the cost budget. See PutChecked.
+func (c *LRU[K, V]) PutWithCostChecked(key K, val V, cost int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, err := c.put(key, val, c.ttl, cost)
+	return err
+}
+
+// Cost returns the total cost of the entries currently in the cache. Len
+// reports the number of entries; the two differ once entries are weighted.
+func (c *LRU[K, V]) Cost() int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.cost
+}
+
+// tooLarge reports whether an entry of the given cost could never fit
+// within the cost budget.
+func (c *LRU[K, V]) tooLarge(cost int64) bool {
+	return c.maxCost > 0 && cost > c.maxCost
+}
+
+// replacementCost returns the cost of kv after its value is replaced with
+// val: the sizer's measure if one is set, otherwise the cost kv was stored
+// with, so a cost given to PutWithCost is kept.
+func (c *LRU[K, V]) replacementCost(kv *entry[K, V], val V) int64 {
+	if c.sizer == nil {
+		return kv.cost
+	}
+	return c.sizer(kv.key, val)
+}
+
+// sizeOf returns the cost of storing key and val.
+func (c *LRU[K, V]) sizeOf(key K, val V) int64 {
+	if c.sizer == nil {
+		return 0
+	}
+	return c.sizer(key, val)
+}
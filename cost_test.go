@@ -0,0 +1,223 @@
+package lru
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestMaxCost verifies variable-cost entries are evicted to fit the budget.
+func TestMaxCost(t *testing.T) {
+	cache, err := NewLRUWithMaxCost[string, string](10, 10, func(k, v string) int64 {
+		return int64(len(v))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cache.Put("a", "aaaa")
+	cache.Put("b", "bbb")
+	cache.Put("c", "ccc")
+	if cache.Len() != 3 || cache.cost != 10 {
+		t.Errorf("expected 3 entries costing 10, got %d costing %d", cache.Len(), cache.cost)
+	}
+
+	cache.Put("d", "dd") // evicts a
+	if cache.Contains("a") {
+		t.Errorf("expected a to be evicted")
+	}
+	if cache.cost != 8 {
+		t.Errorf("expected cost 8, got %d", cache.cost)
+	}
+
+	cache.Put("b", "bbbbbb") // overwrite grows b, evicting c
+	if cache.Contains("c") {
+		t.Errorf("expected c to be evicted")
+	}
+	if !cache.Contains("b") || !cache.Contains("d") {
+		t.Errorf("expected b and d to remain")
+	}
+	if cache.cost != 8 {
+		t.Errorf("expected cost 8, got %d", cache.cost)
+	}
+}
+
+// TestMaxCostOversized verifies an entry larger than the budget is not stored.
+func TestMaxCostOversized(t *testing.T) {
+	cache, _ := NewLRUWithMaxCost[string, string](10, 5, func(k, v string) int64 {
+		return int64(len(v))
+	})
+
+	cache.Put("a", "aa")
+	cache.Put("b", "bb")
+	cache.Put("c", "cccccc")
+
+	if cache.Contains("c") {
+		t.Errorf("expected oversized entry to be rejected")
+	}
+	if !cache.Contains("a") || !cache.Contains("b") {
+		t.Errorf("expected existing entries to survive")
+	}
+
+	cache.Put("a", "aaaaaaa") // oversized overwrite drops the stale value
+	if cache.Contains("a") {
+		t.Errorf("expected oversized overwrite to remove a")
+	}
+	if cache.cost != 2 {
+		t.Errorf("expected cost 2, got %d", cache.cost)
+	}
+}
+
+// TestNewLRUWithMaxCostInvalid ensures invalid limits are rejected.
+func TestNewLRUWithMaxCostInvalid(t *testing.T) {
+	if _, err := NewLRUWithMaxCost[int, int](0, 10, nil); err == nil {
+		t.Errorf("expected error for zero capacity")
+	}
+	if _, err := NewLRUWithMaxCost[int, int](10, 0, nil); err == nil {
+		t.Errorf("expected error for zero max cost")
+	}
+}
+
+// TestPutWithCost verifies explicit costs on overwrite and heavy inserts.
+func TestPutWithCost(t *testing.T) {
+	cache, _ := NewLRUWithMaxCost[int, string](10, 10, nil)
+
+	cache.PutWithCost(1, "one", 2)
+	cache.PutWithCost(2, "two", 2)
+	cache.PutWithCost(3, "three", 2)
+	cache.PutWithCost(1, "uno", 4) // replaces cost 2 with 4
+	if cache.cost != 8 {
+		t.Errorf("expected cost 8 after overwrite, got %d", cache.cost)
+	}
+
+	cache.PutWithCost(4, "four", 6) // evicts 2 and 3
+	if cache.Contains(2) || cache.Contains(3) {
+		t.Errorf("expected keys 2 and 3 to be evicted")
+	}
+	if !cache.Contains(1) || !cache.Contains(4) {
+		t.Errorf("expected keys 1 and 4 to remain")
+	}
+	if cache.cost != 10 {
+		t.Errorf("expected cost 10, got %d", cache.cost)
+	}
+
+	cache.Put(5, "five") // nil sizer stores at zero cost
+	if !cache.Contains(5) || cache.cost != 10 {
+		t.Errorf("expected zero-cost Put to fit, got cost %d", cache.cost)
+	}
+}
+
+// TestPutCheckedTooLarge verifies oversized items are rejected with an error
+// and the prior contents survive.
+func TestPutCheckedTooLarge(t *testing.T) {
+	cache, _ := NewLRUWithMaxCost[string, string](10, 5, func(k, v string) int64 {
+		return int64(len(v))
+	})
+	cache.Put("a", "aa")
+	cache.Put("b", "bbb")
+
+	if err := cache.PutChecked("c", "cccccc"); !errors.Is(err, ErrItemTooLarge) {
+		t.Errorf("expected ErrItemTooLarge, got %v", err)
+	}
+	if err := cache.PutWithCostChecked("d", "d", 6); !errors.Is(err, ErrItemTooLarge) {
+		t.Errorf("expected ErrItemTooLarge, got %v", err)
+	}
+	if !cache.Contains("a") || !cache.Contains("b") || cache.Len() != 2 {
+		t.Errorf("expected prior contents to survive, got %v", cache.Keys())
+	}
+	if cache.Stats().Evictions != 0 {
+		t.Errorf("expected no evictions, got %d", cache.Stats().Evictions)
+	}
+
+	if err := cache.PutChecked("c", "cc"); err != nil {
+		t.Errorf("expected fitting item to be stored, got %v", err)
+	}
+	if err := cache.PutWithCostChecked("d", "d", 1); err != nil {
+		t.Errorf("expected fitting item to be stored, got %v", err)
+	}
+}
+
+// TestCost verifies Len counts items while Cost sums their weights across
+// overwrites and evictions.
+func TestCost(t *testing.T) {
+	cache, _ := NewLRUWithMaxCost[string, int](10, 10, nil)
+	cache.PutWithCost("a", 1, 3)
+	cache.PutWithCost("b", 2, 4)
+	if cache.Len() != 2 || cache.Cost() != 7 {
+		t.Errorf("expected len 2 cost 7, got len %d cost %d", cache.Len(), cache.Cost())
+	}
+
+	cache.PutWithCost("a", 10, 1)
+	if cache.Len() != 2 || cache.Cost() != 5 {
+		t.Errorf("expected len 2 cost 5 after overwrite, got len %d cost %d", cache.Len(), cache.Cost())
+	}
+
+	cache.PutWithCost("c", 3, 8) // evicts "b"; "a" was refreshed by the overwrite
+	if cache.Len() != 2 || cache.Cost() != 9 {
+		t.Errorf("expected len 2 cost 9 after eviction, got len %d cost %d", cache.Len(), cache.Cost())
+	}
+
+	cache.Remove("a")
+	cache.Remove("c")
+	if cache.Len() != 0 || cache.Cost() != 0 {
+		t.Errorf("expected empty cache, got len %d cost %d", cache.Len(), cache.Cost())
+	}
+}
+
+// TestOverwriteGrowsCost verifies overwriting with a larger value evicts
+// other entries, never the one written, until the cache fits.
+func TestOverwriteGrowsCost(t *testing.T) {
+	sizer := func(k string, v string) int64 { return int64(len(v)) }
+	cache, _ := NewLRUWithMaxCost[string, string](10, 10, sizer)
+	cache.Put("a", "aaa")
+	cache.Put("b", "bbb")
+	cache.Put("c", "ccc")
+
+	cache.Put("c", "cccccc") // cost 3+3+6 = 12, evicts "a"
+	if cache.Contains("a") || !cache.Contains("b") || !cache.Contains("c") || cache.Cost() != 9 {
+		t.Errorf("expected a evicted and cost 9, got %v cost %d", cache.Keys(), cache.Cost())
+	}
+
+	cache.Put("a", "a")
+	if !cache.UpdateValue("a", "aaaaaaaaa") { // cost 3+6+9, evicts "b" then "c"
+		t.Fatal("expected UpdateValue to succeed")
+	}
+	if cache.Len() != 1 || !cache.Contains("a") || cache.Cost() != 9 {
+		t.Errorf("expected only a with cost 9, got %v cost %d", cache.Keys(), cache.Cost())
+	}
+}
+
+// TestOverwriteTooLarge verifies an overwrite whose value alone exceeds the
+// budget removes the entry and leaves the others.
+func TestOverwriteTooLarge(t *testing.T) {
+	sizer := func(k string, v string) int64 { return int64(len(v)) }
+	cache, _ := NewLRUWithMaxCost[string, string](10, 5, sizer)
+	cache.Put("a", "a")
+	cache.Put("b", "b")
+
+	if err := cache.PutChecked("a", "aaaaaa"); !errors.Is(err, ErrItemTooLarge) {
+		t.Errorf("expected ErrItemTooLarge, got %v", err)
+	}
+	if cache.Contains("a") || !cache.Contains("b") || cache.Cost() != 1 {
+		t.Errorf("expected only b with cost 1, got %v cost %d", cache.Keys(), cache.Cost())
+	}
+
+	if cache.UpdateValue("b", "bbbbbb") {
+		t.Error("expected oversized UpdateValue to report false")
+	}
+	if cache.Len() != 0 || cache.Cost() != 0 {
+		t.Errorf("expected empty cache, got %v cost %d", cache.Keys(), cache.Cost())
+	}
+}
+
+// TestUpdateValueKeepsExplicitCost verifies a cost given to PutWithCost is
+// kept by UpdateValue when there is no sizer to measure the new value.
+func TestUpdateValueKeepsExplicitCost(t *testing.T) {
+	cache, _ := NewLRUWithMaxCost[string, string](10, 100, nil)
+	cache.PutWithCost("a", "x", 40)
+	if !cache.UpdateValue("a", "y") {
+		t.Fatal("expected UpdateValue to succeed")
+	}
+	if cache.Cost() != 40 {
+		t.Errorf("expected cost 40, got %d", cache.Cost())
+	}
+}
@@ -0,0 +1,70 @@
+package lru
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DumpDebugString returns a human-readable listing of the cache for
+// diagnostics: a header with the length and capacity, then one line per
+// entry from most to least recently used with its key, value and, if it
+// expires, its remaining TTL. Keys and values are formatted with %v. The
+// format is not stable and should not be parsed.
+func (c *LRU[K, V]) DumpDebugString() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var b strings.Builder
+	fmt.Fprintf(&b, "len=%d cap=%d", c.list.Len(), c.cap)
+	if c.maxCost > 0 {
+		fmt.Fprintf(&b, " cost=%d/%d", c.cost, c.maxCost)
+	}
+	b.WriteByte('\n')
+	for el := c.list.Front(); el != nil; el = el.Next() {
+		kv := el.Value.(*entry[K, V])
+		fmt.Fprintf(&b, "%v: %v", kv.key, kv.val)
+		switch {
+		case c.expired(kv):
+			b.WriteString(" (expired)")
+		case !kv.expires.IsZero():
+			fmt.Fprintf(&b, " (ttl %s)", kv.expires.Sub(c.now()))
+		}
+		if kv.negative {
+			b.WriteString(" (negative)")
+		}
+		if kv.pinned {
+			b.WriteString(" (pinned)")
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// DebugValidate checks the internal consistency of the cache and returns an
+// error describing the first problem found, or nil. It verifies that the
+// recency list and the key index hold the same entries, that no key appears
+// twice and that the total cost matches the entries. It is meant for tests
+// and diagnostics; a non-nil result indicates a bug in the cache.
+func (c *LRU[K, V]) DebugValidate() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.list.Len() != len(c.idx) {
+		return fmt.Errorf("list holds %d entries but index holds %d", c.list.Len(), len(c.idx))
+	}
+	seen := make(map[K]bool, len(c.idx))
+	var cost int64
+	for el := c.list.Front(); el != nil; el = el.Next() {
+		kv := el.Value.(*entry[K, V])
+		if seen[kv.key] {
+			return fmt.Errorf("key %v appears twice in the list", kv.key)
+		}
+		seen[kv.key] = true
+		if c.idx[kv.key] != el {
+			return fmt.Errorf("index entry for key %v does not point to its list element", kv.key)
+		}
+		cost += kv.cost
+	}
+	if cost != c.cost {
+		return fmt.Errorf("entries cost %d but total cost is %d", cost, c.cost)
+	}
+	return nil
+}
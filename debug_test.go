@@ -0,0 +1,64 @@
+package lru
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestDumpDebugString verifies entries are listed from most to least
+// recently used with their remaining TTL.
+func TestDumpDebugString(t *testing.T) {
+	clock := newFakeClock()
+	cache, _ := NewLRUWithOptions(WithCapacity[string, int](3), WithClock[string, int](clock))
+	cache.Put("a", 1)
+	cache.PutWithTTL("b", 2, time.Minute)
+	cache.Put("c", 3)
+	cache.Get("a")
+	clock.Advance(10 * time.Second)
+
+	want := "len=3 cap=3\n" +
+		"a: 1\n" +
+		"c: 3\n" +
+		"b: 2 (ttl 50s)\n"
+	if got := cache.DumpDebugString(); got != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, got)
+	}
+
+	clock.Advance(time.Minute)
+	if got := cache.DumpDebugString(); !strings.Contains(got, "b: 2 (expired)") {
+		t.Errorf("expected b to be marked expired, got:\n%s", got)
+	}
+}
+
+// TestDebugValidate verifies a cache passes validation through a mix of
+// operations and that corrupted state is reported.
+func TestDebugValidate(t *testing.T) {
+	cache, _ := NewLRUWithMaxCost[int, int](4, 10, func(k, v int) int64 { return int64(v) })
+	for i := 0; i < 20; i++ {
+		cache.Put(i%6, i%5)
+		cache.Get(i % 3)
+		if i%4 == 0 {
+			cache.Remove(i % 7)
+		}
+		if err := cache.DebugValidate(); err != nil {
+			t.Fatalf("step %d: %v", i, err)
+		}
+	}
+	cache.Resize(2)
+	cache.Purge()
+	if err := cache.DebugValidate(); err != nil {
+		t.Fatal(err)
+	}
+
+	cache.Put(1, 1)
+	cache.cost++
+	if err := cache.DebugValidate(); err == nil {
+		t.Error("expected a cost mismatch to be reported")
+	}
+	cache.cost--
+	delete(cache.idx, 1)
+	if err := cache.DebugValidate(); err == nil {
+		t.Error("expected an index mismatch to be reported")
+	}
+}
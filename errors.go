@@ -0,0 +1,25 @@
+package lru
+
+import "errors"
+
+// Errors returned by the cache, for use with errors.Is.
+var (
+	// ErrInvalidCapacity is returned when a capacity is <= 0.
+	ErrInvalidCapacity = errors.New("capacity must be greater than 0")
+
+	// ErrUnlimitedCapacity is returned by Grow and Shrink when the cache
+	// has no capacity limit to change.
+	ErrUnlimitedCapacity = errors.New("cannot resize unlimited capacity")
+
+	// ErrItemTooLarge is returned when a single entry costs more than the
+	// cache's whole cost budget.
+	ErrItemTooLarge = errors.New("item cost exceeds max cost")
+
+	// ErrCacheFull is returned when the overflow handler rejects a new
+	// entry. See WithOverflowHandler.
+	ErrCacheFull = errors.New("cache is full")
+
+	// ErrPolicyNotCloneable is returned by Clone when the cache's eviction
+	// policy does not implement PolicyCloner.
+	ErrPolicyNotCloneable = errors.New("policy does not implement PolicyCloner")
+)
@@ -0,0 +1,40 @@
+package lru
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestErrInvalidCapacity verifies invalid capacities can be matched with
+// errors.Is and keep their message.
+func TestErrInvalidCapacity(t *testing.T) {
+	for _, capacity := range []int{0, -1, -5} {
+		_, err := NewLRU[int, string](capacity)
+		if !errors.Is(err, ErrInvalidCapacity) {
+			t.Errorf("capacity %d: expected ErrInvalidCapacity, got %v", capacity, err)
+		}
+		if err != nil && err.Error() != "capacity must be greater than 0" {
+			t.Errorf("capacity %d: unexpected message %q", capacity, err)
+		}
+	}
+
+	if _, err := NewLRUWithTTL[int, string](0, 0); !errors.Is(err, ErrInvalidCapacity) {
+		t.Errorf("expected ErrInvalidCapacity from NewLRUWithTTL, got %v", err)
+	}
+
+	cache, _ := NewLRU[int, string](2)
+	if err := cache.Resize(0); !errors.Is(err, ErrInvalidCapacity) {
+		t.Errorf("expected ErrInvalidCapacity from Resize, got %v", err)
+	}
+	if err := cache.Shrink(2); !errors.Is(err, ErrInvalidCapacity) {
+		t.Errorf("expected ErrInvalidCapacity from Shrink, got %v", err)
+	}
+
+	unlimited, _ := NewLRUWithOptions(
+		WithNoCapacityLimit[int, string](),
+		WithMaxCost(10, func(k int, v string) int64 { return 1 }),
+	)
+	if err := unlimited.Grow(1); !errors.Is(err, ErrUnlimitedCapacity) {
+		t.Errorf("expected ErrUnlimitedCapacity from Grow, got %v", err)
+	}
+}
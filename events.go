@@ -0,0 +1,83 @@
+package lru
+
+import "sync"
+
+// EventKind is the kind of change reported by an Event.
+type EventKind int
+
+const (
+	// EventInsert means a key that was not present was stored.
+	EventInsert EventKind = iota
+	// EventUpdate means the value of a present key was replaced.
+	EventUpdate
+	// EventEvict means an entry was evicted to make room.
+	EventEvict
+	// EventExpire means an entry was removed because its TTL passed.
+	EventExpire
+	// EventRemove means an entry was removed by a call such as Remove,
+	// Filter or Purge, or because a new value for it was too large.
+	EventRemove
+)
+
+// String returns the name of the kind.
+func (k EventKind) String() string {
+	switch k {
+	case EventInsert:
+		return "Insert"
+	case EventUpdate:
+		return "Update"
+	case EventEvict:
+		return "Evict"
+	case EventExpire:
+		return "Expire"
+	case EventRemove:
+		return "Remove"
+	default:
+		return "Unknown"
+	}
+}
+
+// Event is a change to the cache delivered to subscribers. Value is the new
+// value for inserts and updates and the removed value otherwise.
+type Event[K comparable, V any] struct {
+	Kind  EventKind
+	Key   K
+	Value V
+}
+
+// Subscribe returns a channel that receives an Event for every insert,
+// update, eviction, expiry and removal, in the order they happen, and a
+// function that ends the subscription and closes the channel. The channel
+// holds up to buffer events; events that arrive while it is full are
+// dropped for this subscriber rather than blocking the cache, so consumers
+// that need every event should keep up or use a larger buffer. A buffer
+// < 0 is treated as 0. Clear does not send events.
+func (c *LRU[K, V]) Subscribe(buffer int) (<-chan Event[K, V], func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan Event[K, V], max(buffer, 0))
+	if c.subs == nil {
+		c.subs = make(map[chan Event[K, V]]struct{})
+	}
+	c.subs[ch] = struct{}{}
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			delete(c.subs, ch)
+			close(ch)
+		})
+	}
+}
+
+// publish sends an event to every subscriber with room for it. Caller must
+// hold the write lock.
+func (c *LRU[K, V]) publish(kind EventKind, key K, value V) {
+	for ch := range c.subs {
+		select {
+		case ch <- Event[K, V]{Kind: kind, Key: key, Value: value}:
+		default:
+		}
+	}
+}
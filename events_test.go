@@ -0,0 +1,110 @@
+package lru
+
+import (
+	"testing"
+	"time"
+)
+
+// drain returns the events buffered in ch without blocking.
+func drain[K comparable, V any](ch <-chan Event[K, V]) []Event[K, V] {
+	var events []Event[K, V]
+	for {
+		select {
+		case ev, ok := <-ch:
+			if !ok {
+				return events
+			}
+			events = append(events, ev)
+		default:
+			return events
+		}
+	}
+}
+
+// TestSubscribe verifies each kind of change is delivered in order.
+func TestSubscribe(t *testing.T) {
+	clock := newFakeClock()
+	cache, _ := NewLRUWithOptions(WithCapacity[string, int](2), WithClock[string, int](clock))
+	events, unsubscribe := cache.Subscribe(16)
+	defer unsubscribe()
+
+	cache.Put("a", 1)
+	cache.Put("a", 2)
+	cache.Put("b", 3)
+	cache.Put("c", 4) // evicts a
+	cache.Remove("b")
+	cache.PutWithTTL("d", 5, time.Second)
+	clock.Advance(time.Second)
+	cache.Get("d")
+
+	want := []Event[string, int]{
+		{EventInsert, "a", 1},
+		{EventUpdate, "a", 2},
+		{EventInsert, "b", 3},
+		{EventInsert, "c", 4},
+		{EventEvict, "a", 2},
+		{EventRemove, "b", 3},
+		{EventInsert, "d", 5},
+		{EventExpire, "d", 5},
+	}
+	got := drain(events)
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("event %d: expected %v, got %v", i, want[i], got[i])
+		}
+	}
+}
+
+// TestSubscribeFullBuffer verifies events are dropped rather than blocking
+// when a subscriber falls behind.
+func TestSubscribeFullBuffer(t *testing.T) {
+	cache, _ := NewLRU[int, int](10)
+	events, unsubscribe := cache.Subscribe(2)
+	defer unsubscribe()
+	for i := 0; i < 5; i++ {
+		cache.Put(i, i)
+	}
+	got := drain(events)
+	if len(got) != 2 || got[0].Key != 0 || got[1].Key != 1 {
+		t.Errorf("expected the first 2 events, got %v", got)
+	}
+}
+
+// TestUnsubscribe verifies unsubscribing closes the channel and stops
+// delivery, and may be called more than once.
+func TestUnsubscribe(t *testing.T) {
+	cache, _ := NewLRU[int, int](10)
+	events, unsubscribe := cache.Subscribe(4)
+	other, unsubscribeOther := cache.Subscribe(4)
+	defer unsubscribeOther()
+
+	cache.Put(1, 1)
+	unsubscribe()
+	unsubscribe()
+	cache.Put(2, 2)
+
+	if got := drain(events); len(got) != 1 || got[0].Key != 1 {
+		t.Errorf("expected only the event before unsubscribing, got %v", got)
+	}
+	if _, ok := <-events; ok {
+		t.Error("expected the channel to be closed")
+	}
+	if got := drain(other); len(got) != 2 {
+		t.Errorf("expected the other subscriber to get 2 events, got %v", got)
+	}
+}
+
+// TestSubscribeNegativeBuffer verifies a negative buffer is treated as 0
+// instead of panicking.
+func TestSubscribeNegativeBuffer(t *testing.T) {
+	cache, _ := NewLRU[string, int](2)
+	events, unsubscribe := cache.Subscribe(-1)
+	defer unsubscribe()
+	if cap(events) != 0 {
+		t.Errorf("expected an unbuffered channel, got capacity %d", cap(events))
+	}
+	cache.Put("a", 1) // must not block with no reader
+}
@@ -0,0 +1,87 @@
+package lru
+
+import "sync"
+
+// evictQueue runs eviction callbacks in order on a background goroutine.
+// The goroutine is started when work is queued and exits once the queue is
+// empty, so an idle queue holds no goroutine.
+type evictQueue[K comparable, V any] struct {
+	mu      sync.Mutex
+	idle    *sync.Cond // signalled when the worker exits
+	pending []evictCall[K, V]
+	running bool
+}
+
+// evictCall is a queued eviction callback invocation.
+type evictCall[K comparable, V any] struct {
+	fn    func(key K, value V)
+	key   K
+	value V
+}
+
+func newEvictQueue[K comparable, V any]() *evictQueue[K, V] {
+	q := &evictQueue[K, V]{}
+	q.idle = sync.NewCond(&q.mu)
+	return q
+}
+
+// push queues a call of fn, starting the worker if needed.
+func (q *evictQueue[K, V]) push(fn func(key K, value V), key K, value V) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.pending = append(q.pending, evictCall[K, V]{fn: fn, key: key, value: value})
+	if !q.running {
+		q.running = true
+		go q.run()
+	}
+}
+
+// run makes the queued calls until the queue is empty.
+func (q *evictQueue[K, V]) run() {
+	for {
+		q.mu.Lock()
+		calls := q.pending
+		q.pending = nil
+		if len(calls) == 0 {
+			q.running = false
+			q.idle.Broadcast()
+			q.mu.Unlock()
+			return
+		}
+		q.mu.Unlock()
+		for _, call := range calls {
+			call.fn(call.key, call.value)
+		}
+	}
+}
+
+// flush waits until every queued call has been made.
+func (q *evictQueue[K, V]) flush() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for q.running {
+		q.idle.Wait()
+	}
+}
+
+// notifyEvict calls the eviction callback, if set, or queues the call if
+// the callback is asynchronous. Caller must hold the write lock.
+func (c *LRU[K, V]) notifyEvict(key K, value V) {
+	if c.onEvict == nil {
+		return
+	}
+	if c.evictQ != nil {
+		c.evictQ.push(c.onEvict, key, value)
+		return
+	}
+	c.onEvict(key, value)
+}
+
+// FlushEvictions waits until all queued eviction callbacks have run. It
+// returns immediately if the eviction callback is synchronous. Callbacks
+// queued while it waits are waited for too.
+func (c *LRU[K, V]) FlushEvictions() {
+	if c.evictQ != nil {
+		c.evictQ.flush()
+	}
+}
@@ -0,0 +1,68 @@
+package lru
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestAsyncEvictionCallback verifies a slow callback does not block other
+// operations and every callback eventually runs in order.
+func TestAsyncEvictionCallback(t *testing.T) {
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var evicted []int
+	cache, _ := NewLRUWithOptions(
+		WithCapacity[int, int](1),
+		WithAsyncEvictionCallback[int, int](),
+		WithEvictionCallback(func(k, v int) {
+			<-release
+			mu.Lock()
+			evicted = append(evicted, k)
+			mu.Unlock()
+		}),
+	)
+	for i := 0; i < 5; i++ {
+		cache.Put(i, i)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		cache.Get(4)
+		cache.Put(5, 5)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected operations not to wait for the callback")
+	}
+
+	close(release)
+	cache.FlushEvictions()
+	mu.Lock()
+	defer mu.Unlock()
+	if len(evicted) != 5 {
+		t.Fatalf("expected 5 callbacks, got %v", evicted)
+	}
+	for i, k := range evicted {
+		if k != i {
+			t.Errorf("expected callbacks in eviction order, got %v", evicted)
+			break
+		}
+	}
+}
+
+// TestFlushEvictionsSync verifies FlushEvictions returns at once without
+// an asynchronous callback.
+func TestFlushEvictionsSync(t *testing.T) {
+	cache, _ := NewLRU[int, int](1)
+	called := false
+	cache.SetEvictionCallback(func(int, int) { called = true })
+	cache.Put(1, 1)
+	cache.Put(2, 2)
+	cache.FlushEvictions()
+	if !called {
+		t.Error("expected synchronous callback to have run")
+	}
+}
@@ -0,0 +1,31 @@
+package lru
+
+import "testing"
+
+// TestFIFOIgnoresAccess verifies access does not protect entries in FIFO mode
+// but does in the default LRU mode.
+func TestFIFOIgnoresAccess(t *testing.T) {
+	fifoCache, err := NewLRUWithOptions(WithCapacity[int, string](2), WithFIFO[int, string]())
+	if err != nil {
+		t.Fatal(err)
+	}
+	lruCache, _ := NewLRU[int, string](2)
+
+	for _, cache := range []*LRU[int, string]{fifoCache, lruCache} {
+		cache.Put(1, "one")
+		cache.Put(2, "two")
+		cache.Get(1)
+		cache.Put(1, "uno")
+		cache.Put(3, "three")
+	}
+
+	if fifoCache.Contains(1) || !fifoCache.Contains(2) {
+		t.Errorf("expected FIFO to evict the first inserted key, got %v", fifoCache.Keys())
+	}
+	if val, ok := fifoCache.Peek(1); ok {
+		t.Errorf("expected key 1 to be gone, got %v", val)
+	}
+	if !lruCache.Contains(1) || lruCache.Contains(2) {
+		t.Errorf("expected LRU to evict the least recently used key, got %v", lruCache.Keys())
+	}
+}
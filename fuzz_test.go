@@ -0,0 +1,35 @@
+package lru
+
+import "testing"
+
+// FuzzLRU applies the operations encoded in ops to a cache and checks its
+// invariants after each one. Every two bytes encode an operation and a key:
+// the low two bits of the first byte select Put, Get, Remove or Resize.
+func FuzzLRU(f *testing.F) {
+	f.Add([]byte{0, 1, 0, 2, 0, 3, 0, 4, 0, 5})             // fill and evict
+	f.Add([]byte{0, 1, 0, 1, 0, 2, 1, 1, 0, 3, 0, 4, 1, 2}) // overwrite and promote
+	f.Add([]byte{0, 1, 0, 2, 2, 1, 2, 9, 0, 3})             // remove present and absent
+	f.Add([]byte{0, 1, 0, 2, 0, 3, 3, 1, 0, 4, 3, 6, 0, 5}) // shrink and grow
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		cache, _ := NewLRU[byte, int](3)
+		for i := 0; i+1 < len(ops); i += 2 {
+			key := ops[i+1] % 8
+			switch ops[i] % 4 {
+			case 0:
+				cache.Put(key, i)
+			case 1:
+				cache.Get(key)
+			case 2:
+				cache.Remove(key)
+			case 3:
+				cache.Resize(int(key%4) + 1)
+			}
+			if cache.Len() > cache.Cap() {
+				t.Fatalf("cache holds %d entries, over capacity %d", cache.Len(), cache.Cap())
+			}
+			if err := cache.DebugValidate(); err != nil {
+				t.Fatal(err)
+			}
+		}
+	})
+}
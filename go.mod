@@ -1,3 +1,3 @@
 module github.com/Prajwal306/go-lru-cache
 
-go 1.20
+go 1.23
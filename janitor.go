@@ -0,0 +1,92 @@
+package lru
+
+import (
+	"container/list"
+	"time"
+)
+
+// janitor periodically removes expired entries from a cache.
+type janitor struct {
+	quit chan struct{}
+	done chan struct{}
+}
+
+// StartJanitor starts a background goroutine that removes expired entries
+// every interval, calling the expiration callback for each, or the eviction
+// callback if none is set. Any janitor that is already running is stopped
+// first. An interval <= 0 only stops the running janitor. Call StopJanitor
+// to stop it.
+func (c *LRU[K, V]) StartJanitor(interval time.Duration) {
+	c.mu.Lock()
+	// Stopping a janitor means waiting for it without the lock, so another
+	// call may install one meanwhile; loop until none is left, then install
+	// under the same lock so no janitor is ever overwritten.
+	for c.janitor != nil {
+		old := c.janitor
+		c.janitor = nil
+		c.mu.Unlock()
+		old.stop()
+		c.mu.Lock()
+	}
+	if interval <= 0 {
+		c.mu.Unlock()
+		return
+	}
+	j := &janitor{
+		quit: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	c.janitor = j
+	c.mu.Unlock()
+
+	go func() {
+		defer close(j.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				c.deleteExpired()
+			case <-j.quit:
+				return
+			}
+		}
+	}()
+}
+
+// StopJanitor stops the background janitor and waits for it to exit.
+// It is a no-op if no janitor is running.
+func (c *LRU[K, V]) StopJanitor() {
+	c.mu.Lock()
+	j := c.janitor
+	c.janitor = nil
+	c.mu.Unlock()
+	if j != nil {
+		j.stop()
+	}
+}
+
+// stop signals the janitor to exit and waits for it.
+func (j *janitor) stop() {
+	close(j.quit)
+	<-j.done
+}
+
+// deleteExpired removes all expired entries from the cache.
+func (c *LRU[K, V]) deleteExpired() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.expireAll(nil)
+}
+
+// expireAll removes all expired entries other than keep.
+// Caller must hold the write lock.
+func (c *LRU[K, V]) expireAll(keep *list.Element) {
+	for el := c.list.Back(); el != nil; {
+		prev := el.Prev()
+		if el != keep && c.expired(el.Value.(*entry[K, V])) {
+			c.expire(el)
+		}
+		el = prev
+	}
+}
@@ -0,0 +1,103 @@
+package lru
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestJanitor verifies expired entries are removed without an intervening Get.
+func TestJanitor(t *testing.T) {
+	clock := newFakeClock()
+	cache, _ := NewLRUWithOptions(WithCapacity[int, string](4), WithClock[int, string](clock))
+	var expired atomic.Int32
+	cache.SetEvictionCallback(func(k int, v string) {
+		expired.Add(1)
+	})
+
+	cache.PutWithTTL(1, "one", time.Second)
+	cache.PutWithTTL(2, "two", time.Second)
+	cache.Put(3, "three")
+
+	cache.StartJanitor(time.Millisecond)
+	defer cache.StopJanitor()
+
+	clock.Advance(time.Second)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for cache.Len() > 1 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	if cache.Len() != 1 {
+		t.Errorf("expected janitor to remove expired entries, got len %d", cache.Len())
+	}
+	if expired.Load() != 2 {
+		t.Errorf("expected callback for 2 expired entries, got %d", expired.Load())
+	}
+	if _, ok := cache.Peek(3); !ok {
+		t.Errorf("expected key 3 to survive")
+	}
+}
+
+// TestStopJanitor verifies StopJanitor halts sweeping and is safe to repeat.
+func TestStopJanitor(t *testing.T) {
+	clock := newFakeClock()
+	cache, _ := NewLRUWithOptions(WithCapacity[int, string](4), WithClock[int, string](clock))
+
+	cache.StopJanitor() // no-op without a janitor
+	cache.StartJanitor(time.Millisecond)
+	cache.StartJanitor(time.Millisecond) // restarts the janitor
+	cache.StopJanitor()
+	cache.StopJanitor()
+
+	cache.PutWithTTL(1, "one", time.Second)
+	clock.Advance(time.Second)
+	time.Sleep(10 * time.Millisecond)
+
+	if cache.Len() != 1 {
+		t.Errorf("expected stopped janitor not to sweep, got len %d", cache.Len())
+	}
+}
+
+// TestStartJanitorInvalidInterval verifies a non-positive interval stops the
+// running janitor instead of starting one.
+func TestStartJanitorInvalidInterval(t *testing.T) {
+	cache, _ := NewLRU[int, int](1)
+	cache.StartJanitor(time.Millisecond)
+	cache.StartJanitor(0)
+	cache.StartJanitor(-time.Second)
+	if cache.janitor != nil {
+		t.Error("expected no janitor to be running")
+	}
+	cache.StopJanitor()
+}
+
+// TestStartJanitorConcurrent verifies concurrent StartJanitor calls leave a
+// single janitor, so StopJanitor stops every goroutine they started.
+func TestStartJanitorConcurrent(t *testing.T) {
+	cache, _ := NewLRU[int, int](4)
+	before := runtime.NumGoroutine()
+	for trial := 0; trial < 2000; trial++ {
+		var wg sync.WaitGroup
+		for i := 0; i < 32; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				cache.StartJanitor(time.Hour)
+			}()
+		}
+		wg.Wait()
+	}
+	cache.StopJanitor()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if n := runtime.NumGoroutine(); n > before {
+		t.Errorf("expected all janitors to stop, %d goroutines left over", n-before)
+	}
+}
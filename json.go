@@ -0,0 +1,35 @@
+package lru
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// MarshalJSON encodes the live entries as a JSON array of {"key", "value"}
+// objects ordered from least to most recently used. Expiry times are not
+// encoded. Returns an error if K or V cannot be encoded as JSON.
+func (c *LRU[K, V]) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal(c.Snapshot())
+	if err != nil {
+		return nil, fmt.Errorf("lru: marshal entries: %w", err)
+	}
+	return b, nil
+}
+
+// UnmarshalJSON replaces the contents of the cache with entries produced by
+// MarshalJSON, restoring their recency order. Entries are inserted from least
+// to most recently used, so if there are more than the capacity the oldest
+// are evicted as they load. Loaded entries get the default TTL. The cache
+// must have been created with a constructor; its configuration is kept.
+func (c *LRU[K, V]) UnmarshalJSON(data []byte) error {
+	if c.list == nil {
+		return errors.New("lru: unmarshal into uninitialized cache")
+	}
+	var recs []Entry[K, V]
+	if err := json.Unmarshal(data, &recs); err != nil {
+		return fmt.Errorf("lru: unmarshal entries: %w", err)
+	}
+	c.restore(recs)
+	return nil
+}
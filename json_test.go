@@ -0,0 +1,70 @@
+package lru
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestJSONRoundTrip verifies contents and recency order survive marshaling.
+func TestJSONRoundTrip(t *testing.T) {
+	cache, _ := NewLRU[string, int](3)
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+	cache.Get("a")
+
+	data, err := json.Marshal(cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[{"key":"b","value":2},{"key":"c","value":3},{"key":"a","value":1}]`
+	if string(data) != want {
+		t.Errorf("expected %s, got %s", want, data)
+	}
+
+	restored, _ := NewLRU[string, int](3)
+	restored.Put("stale", 0)
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatal(err)
+	}
+
+	keys := restored.Keys()
+	if len(keys) != 3 || keys[0] != "b" || keys[1] != "c" || keys[2] != "a" {
+		t.Errorf("expected order [b c a], got %v", keys)
+	}
+	for k, v := range map[string]int{"a": 1, "b": 2, "c": 3} {
+		if got, ok := restored.Peek(k); !ok || got != v {
+			t.Errorf("expected %s=%d, got %d", k, v, got)
+		}
+	}
+}
+
+// TestUnmarshalJSONEvicts verifies loading respects capacity.
+func TestUnmarshalJSONEvicts(t *testing.T) {
+	cache, _ := NewLRU[string, int](2)
+	data := []byte(`[{"key":"a","value":1},{"key":"b","value":2},{"key":"c","value":3}]`)
+
+	if err := json.Unmarshal(data, cache); err != nil {
+		t.Fatal(err)
+	}
+
+	keys := cache.Keys()
+	if len(keys) != 2 || keys[0] != "b" || keys[1] != "c" {
+		t.Errorf("expected [b c], got %v", keys)
+	}
+}
+
+// TestMarshalJSONUnsupported verifies a clear error for non-JSON values.
+func TestMarshalJSONUnsupported(t *testing.T) {
+	cache, _ := NewLRU[string, func()](1)
+	cache.Put("a", func() {})
+
+	if _, err := json.Marshal(cache); err == nil {
+		t.Errorf("expected error marshaling func values")
+	}
+
+	var zero LRU[string, int]
+	if err := zero.UnmarshalJSON([]byte(`[]`)); err == nil {
+		t.Errorf("expected error unmarshaling into an uninitialized cache")
+	}
+}
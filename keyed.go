@@ -0,0 +1,118 @@
+package lru
+
+import (
+	"errors"
+	"sync"
+)
+
+// KeyedLRU is a thread-safe LRU cache for keys that are not comparable,
+// such as structs containing slices or maps. Each key is mapped to a string
+// by a key function and entries are indexed by that string. Keys whose
+// strings collide share a slot and are told apart with an equality function.
+// Recency and eviction are tracked per slot.
+type KeyedLRU[K any, V any] struct {
+	mu    sync.Mutex // serializes slot updates
+	inner *LRU[string, []keyedEntry[K, V]]
+	cap   int
+	keyFn func(K) string
+	equal func(a, b K) bool
+}
+
+// keyedEntry is an original key and its value within a slot.
+type keyedEntry[K any, V any] struct {
+	key K
+	val V
+}
+
+// NewKeyedLRU creates a cache holding up to capacity entries, indexed by
+// keyFn and disambiguated by equal. Returns an error if capacity <= 0 or
+// either function is nil.
+func NewKeyedLRU[K any, V any](capacity int, keyFn func(K) string, equal func(a, b K) bool) (*KeyedLRU[K, V], error) {
+	if keyFn == nil || equal == nil {
+		return nil, errors.New("key and equality functions must not be nil")
+	}
+	inner, err := NewLRUWithOptions(
+		WithCapacity[string, []keyedEntry[K, V]](capacity),
+		WithMaxCost(int64(capacity), func(_ string, slot []keyedEntry[K, V]) int64 {
+			return int64(len(slot))
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyedLRU[K, V]{inner: inner, cap: capacity, keyFn: keyFn, equal: equal}, nil
+}
+
+// Get retrieves the value for the given key if present.
+// Moves the key's slot to the front of the cache.
+func (k *KeyedLRU[K, V]) Get(key K) (V, bool) {
+	slot, _ := k.inner.Get(k.keyFn(key))
+	if i := k.find(slot, key); i >= 0 {
+		return slot[i].val, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains reports whether the key is in the cache without updating its recency.
+func (k *KeyedLRU[K, V]) Contains(key K) bool {
+	slot, _ := k.inner.Peek(k.keyFn(key))
+	return k.find(slot, key) >= 0
+}
+
+// Put inserts or updates the value for the given key.
+// If capacity is exceeded, evicts the least recently used slot.
+func (k *KeyedLRU[K, V]) Put(key K, val V) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	id := k.keyFn(key)
+	old, _ := k.inner.Peek(id)
+	// Slots are copied on write so readers never see them change.
+	slot := make([]keyedEntry[K, V], 0, len(old)+1)
+	slot = append(slot, keyedEntry[K, V]{key: key, val: val})
+	for _, e := range old {
+		if !k.equal(e.key, key) && len(slot) < k.cap {
+			slot = append(slot, e)
+		}
+	}
+	k.inner.Put(id, slot)
+}
+
+// Remove deletes the entry for the given key.
+// Returns true if the key was present.
+func (k *KeyedLRU[K, V]) Remove(key K) bool {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	id := k.keyFn(key)
+	old, _ := k.inner.Peek(id)
+	i := k.find(old, key)
+	if i < 0 {
+		return false
+	}
+	if len(old) == 1 {
+		k.inner.Remove(id)
+		return true
+	}
+	slot := make([]keyedEntry[K, V], 0, len(old)-1)
+	slot = append(slot, old[:i]...)
+	slot = append(slot, old[i+1:]...)
+	k.inner.UpdateValue(id, slot)
+	return true
+}
+
+// Len returns the current number of entries in the cache.
+func (k *KeyedLRU[K, V]) Len() int {
+	k.inner.mu.RLock()
+	defer k.inner.mu.RUnlock()
+	return int(k.inner.cost)
+}
+
+// find returns the index of key in slot, or -1.
+func (k *KeyedLRU[K, V]) find(slot []keyedEntry[K, V], key K) int {
+	for i, e := range slot {
+		if k.equal(e.key, key) {
+			return i
+		}
+	}
+	return -1
+}
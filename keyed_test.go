@@ -0,0 +1,110 @@
+package lru
+
+import (
+	"fmt"
+	"testing"
+)
+
+// query is a key type that is not comparable.
+type query struct {
+	Table string
+	IDs   []int
+}
+
+func queryEqual(a, b query) bool {
+	if a.Table != b.Table || len(a.IDs) != len(b.IDs) {
+		return false
+	}
+	for i := range a.IDs {
+		if a.IDs[i] != b.IDs[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// TestKeyedLRU verifies hits, misses and eviction with non-comparable keys.
+func TestKeyedLRU(t *testing.T) {
+	cache, err := NewKeyedLRU[query, string](2, func(q query) string {
+		return fmt.Sprint(q)
+	}, queryEqual)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := query{"users", []int{1, 2}}
+	b := query{"users", []int{3}}
+	c := query{"orders", []int{1, 2}}
+
+	cache.Put(a, "a")
+	cache.Put(b, "b")
+	if val, ok := cache.Get(query{"users", []int{1, 2}}); !ok || val != "a" {
+		t.Errorf("expected a for an equal key, got %v", val)
+	}
+	if _, ok := cache.Get(c); ok {
+		t.Errorf("expected miss for c")
+	}
+
+	cache.Put(c, "c") // evicts b, the least recently used
+	if cache.Contains(b) {
+		t.Errorf("expected b to be evicted")
+	}
+	if !cache.Contains(a) || !cache.Contains(c) {
+		t.Errorf("expected a and c to remain")
+	}
+	if cache.Len() != 2 {
+		t.Errorf("expected len 2, got %d", cache.Len())
+	}
+}
+
+// TestKeyedLRUCollisions verifies colliding keys are told apart by equality.
+func TestKeyedLRUCollisions(t *testing.T) {
+	cache, _ := NewKeyedLRU[query, int](3, func(q query) string {
+		return q.Table // every key of a table collides
+	}, queryEqual)
+
+	q1 := query{"t", []int{1}}
+	q2 := query{"t", []int{2}}
+	q3 := query{"t", []int{3}}
+
+	cache.Put(q1, 1)
+	cache.Put(q2, 2)
+	cache.Put(q1, 10)
+
+	if val, ok := cache.Get(q1); !ok || val != 10 {
+		t.Errorf("expected 10, got %v", val)
+	}
+	if val, ok := cache.Get(q2); !ok || val != 2 {
+		t.Errorf("expected 2, got %v", val)
+	}
+	if _, ok := cache.Get(q3); ok {
+		t.Errorf("expected miss for q3")
+	}
+	if cache.Len() != 2 {
+		t.Errorf("expected len 2, got %d", cache.Len())
+	}
+
+	if !cache.Remove(q1) || cache.Contains(q1) {
+		t.Errorf("expected q1 to be removed")
+	}
+	if !cache.Contains(q2) {
+		t.Errorf("expected q2 to survive removal of a colliding key")
+	}
+	if cache.Remove(q3) {
+		t.Errorf("expected Remove(q3) to report false")
+	}
+}
+
+// TestNewKeyedLRUInvalid ensures invalid arguments are rejected.
+func TestNewKeyedLRUInvalid(t *testing.T) {
+	keyFn := func(q query) string { return q.Table }
+	if _, err := NewKeyedLRU[query, int](0, keyFn, queryEqual); err == nil {
+		t.Errorf("expected error for zero capacity")
+	}
+	if _, err := NewKeyedLRU[query, int](1, nil, queryEqual); err == nil {
+		t.Errorf("expected error for nil key function")
+	}
+	if _, err := NewKeyedLRU[query, int](1, keyFn, nil); err == nil {
+		t.Errorf("expected error for nil equality function")
+	}
+}
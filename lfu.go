@@ -0,0 +1,122 @@
+package lru
+
+import "container/list"
+
+// LFUPolicy evicts the least frequently used entry, breaking ties by
+// recency. Frequencies count the insert plus every access, and victims are
+// found in O(1). The list is kept in recency order.
+type LFUPolicy struct {
+	buckets *list.List                 // holds *lfuBucket in ascending frequency
+	nodes   map[*list.Element]*lfuNode // keyed by cache element
+}
+
+// lfuBucket holds the cache elements accessed exactly freq times, most
+// recently used first.
+type lfuBucket struct {
+	freq  int
+	items *list.List // holds cache *list.Element
+}
+
+// lfuNode locates a cache element within its frequency bucket.
+type lfuNode struct {
+	bucket *list.Element // element of LFUPolicy.buckets
+	item   *list.Element // element of the bucket's items
+}
+
+// NewLFUPolicy creates an LFU policy for a single cache.
+func NewLFUPolicy() *LFUPolicy {
+	return &LFUPolicy{
+		buckets: list.New(),
+		nodes:   make(map[*list.Element]*lfuNode),
+	}
+}
+
+// OnInsert records el with a frequency of one.
+func (p *LFUPolicy) OnInsert(l *list.List, el *list.Element) {
+	b := p.buckets.Front()
+	if b == nil || b.Value.(*lfuBucket).freq != 1 {
+		b = p.buckets.PushFront(&lfuBucket{freq: 1, items: list.New()})
+	}
+	p.nodes[el] = &lfuNode{bucket: b, item: b.Value.(*lfuBucket).items.PushFront(el)}
+}
+
+// OnAccess increments the frequency of el and moves it to the front of l.
+func (p *LFUPolicy) OnAccess(l *list.List, el *list.Element) {
+	l.MoveToFront(el)
+	n, ok := p.nodes[el]
+	if !ok {
+		return
+	}
+	cur := n.bucket.Value.(*lfuBucket)
+	next := n.bucket.Next()
+	if next == nil || next.Value.(*lfuBucket).freq != cur.freq+1 {
+		next = p.buckets.InsertAfter(&lfuBucket{freq: cur.freq + 1, items: list.New()}, n.bucket)
+	}
+	p.unlink(n)
+	n.bucket = next
+	n.item = next.Value.(*lfuBucket).items.PushFront(el)
+}
+
+// OnRemove forgets el.
+func (p *LFUPolicy) OnRemove(el *list.Element) {
+	if n, ok := p.nodes[el]; ok {
+		p.unlink(n)
+		delete(p.nodes, el)
+	}
+}
+
+// Victim walks elements from least to most frequently used.
+func (p *LFUPolicy) Victim(l *list.List, after *list.Element) *list.Element {
+	var b, item *list.Element
+	if after == nil {
+		b = p.buckets.Front()
+	} else if n, ok := p.nodes[after]; ok {
+		b, item = n.bucket, n.item.Prev()
+		if item == nil {
+			b = b.Next()
+		}
+	}
+	if item == nil && b != nil {
+		item = b.Value.(*lfuBucket).items.Back()
+	}
+	if item == nil {
+		return nil
+	}
+	return item.Value.(*list.Element)
+}
+
+// Reset forgets all elements.
+func (p *LFUPolicy) Reset() {
+	p.buckets.Init()
+	p.nodes = make(map[*list.Element]*lfuNode)
+}
+
+// ClonePolicy returns an LFU policy holding the copied elements with the
+// same frequencies and tie-breaking order.
+func (p *LFUPolicy) ClonePolicy(elems map[*list.Element]*list.Element) Policy {
+	cp := NewLFUPolicy()
+	for b := p.buckets.Front(); b != nil; b = b.Next() {
+		bucket := b.Value.(*lfuBucket)
+		var nb *list.Element
+		for item := bucket.items.Front(); item != nil; item = item.Next() {
+			el, ok := elems[item.Value.(*list.Element)]
+			if !ok {
+				continue
+			}
+			if nb == nil {
+				nb = cp.buckets.PushBack(&lfuBucket{freq: bucket.freq, items: list.New()})
+			}
+			cp.nodes[el] = &lfuNode{bucket: nb, item: nb.Value.(*lfuBucket).items.PushBack(el)}
+		}
+	}
+	return cp
+}
+
+// unlink removes n from its bucket, dropping the bucket if it empties.
+func (p *LFUPolicy) unlink(n *lfuNode) {
+	b := n.bucket.Value.(*lfuBucket)
+	b.items.Remove(n.item)
+	if b.items.Len() == 0 {
+		p.buckets.Remove(n.bucket)
+	}
+}
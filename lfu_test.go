@@ -0,0 +1,80 @@
+package lru
+
+import "testing"
+
+// TestLFUKeepsHotKey verifies a frequently used key survives a burst of cold
+// inserts in LFU mode but not in LRU mode.
+func TestLFUKeepsHotKey(t *testing.T) {
+	lfuCache, err := NewLRUWithOptions(WithCapacity[int, int](3), WithLFU[int, int]())
+	if err != nil {
+		t.Fatal(err)
+	}
+	lruCache, _ := NewLRU[int, int](3)
+
+	for _, cache := range []*LRU[int, int]{lfuCache, lruCache} {
+		cache.Put(0, 0)
+		for i := 0; i < 5; i++ {
+			cache.Get(0)
+		}
+		for i := 1; i <= 10; i++ {
+			cache.Put(i, i)
+		}
+	}
+
+	if !lfuCache.Contains(0) {
+		t.Errorf("expected hot key to survive in LFU mode")
+	}
+	if lruCache.Contains(0) {
+		t.Errorf("expected hot key to be evicted in LRU mode")
+	}
+}
+
+// TestLFUTieBreak verifies equal frequencies evict the least recently used.
+func TestLFUTieBreak(t *testing.T) {
+	cache, _ := NewLRUWithOptions(WithCapacity[int, string](3), WithLFU[int, string]())
+
+	cache.Put(1, "one")
+	cache.Put(2, "two")
+	cache.Put(3, "three")
+	cache.Get(1)
+	cache.Get(2)         // 1 and 2 have frequency 2, 3 has frequency 1
+	cache.Put(4, "four") // evicts 3, the only frequency 1 entry
+	cache.Get(4)         // all have frequency 2; 1 is least recent
+	cache.Put(5, "five") // evicts 1, never the entry being inserted
+	cache.Put(6, "six")  // evicts 5, the only frequency 1 entry
+
+	if cache.Contains(3) || cache.Contains(5) {
+		t.Errorf("expected keys 3 and 5 to be evicted")
+	}
+	if cache.Contains(1) {
+		t.Errorf("expected least recent of the tied keys to be evicted")
+	}
+	if !cache.Contains(2) || !cache.Contains(4) || !cache.Contains(6) {
+		t.Errorf("expected keys 2, 4 and 6 to remain, got %v", cache.Keys())
+	}
+}
+
+// TestLFURemoveAndClear verifies frequency tracking follows removals.
+func TestLFURemoveAndClear(t *testing.T) {
+	cache, _ := NewLRUWithOptions(WithCapacity[int, string](2), WithLFU[int, string]())
+
+	cache.Put(1, "one")
+	cache.Get(1)
+	cache.Remove(1)
+	cache.Put(2, "two")
+	cache.Put(3, "three")
+	cache.Put(4, "four") // evicts 2
+
+	if cache.Contains(2) || !cache.Contains(3) || !cache.Contains(4) {
+		t.Errorf("expected [3 4], got %v", cache.Keys())
+	}
+	p := cache.policy.(*LFUPolicy)
+	if len(p.nodes) != 2 {
+		t.Errorf("expected 2 tracked nodes, got %d", len(p.nodes))
+	}
+
+	cache.Clear()
+	if len(p.nodes) != 0 || p.buckets.Len() != 0 {
+		t.Errorf("expected Clear to reset frequency tracking")
+	}
+}
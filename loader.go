@@ -0,0 +1,148 @@
+package lru
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// errLoaderPanicked is returned to callers waiting on a loader that panicked.
+var errLoaderPanicked = errors.New("loader panicked")
+
+// call is an in-flight or completed loader invocation.
+type call[V any] struct {
+	wg  sync.WaitGroup
+	val V
+	err error
+}
+
+// GetOrLoad returns the value for the key if present and promotes it.
+// Otherwise it calls loader without holding the cache lock and stores the
+// result on success. Concurrent calls for the same key share a single loader
+// invocation and all receive its result. If another caller stored the key
+// while loader ran, the stored value wins and is returned. Errors from loader
+// are returned as is and nothing is stored.
+func (c *LRU[K, V]) GetOrLoad(key K, loader func(K) (V, error)) (V, error) {
+	if val, ok := c.Get(key); ok {
+		return val, nil
+	}
+	return c.load(key, loader)
+}
+
+// EnsureLoaded makes sure key is cached, loading it like GetOrLoad on a
+// miss, without returning the value. On a hit the entry is promoted and
+// loader is not called. It is meant for prefetching and warm-up jobs.
+func (c *LRU[K, V]) EnsureLoaded(key K, loader func(K) (V, error)) error {
+	_, err := c.GetOrLoad(key, loader)
+	return err
+}
+
+// load runs loader for key, coalescing concurrent calls for the same key.
+func (c *LRU[K, V]) load(key K, loader func(K) (V, error)) (V, error) {
+	c.loadMu.Lock()
+	if cl, ok := c.loads[key]; ok {
+		c.loadMu.Unlock()
+		cl.wg.Wait()
+		if cl.err != nil {
+			return cl.val, cl.err
+		}
+		return c.copyValue(cl.val), nil
+	}
+	if c.loads == nil {
+		c.loads = make(map[K]*call[V])
+	}
+	cl := &call[V]{err: errLoaderPanicked}
+	cl.wg.Add(1)
+	c.loads[key] = cl
+	c.loadMu.Unlock()
+
+	defer func() {
+		c.loadMu.Lock()
+		delete(c.loads, key)
+		c.loadMu.Unlock()
+		cl.wg.Done()
+	}()
+
+	cl.val, cl.err = loader(key)
+	if cl.err != nil {
+		return cl.val, cl.err
+	}
+	cl.val, _ = c.GetOrPut(key, cl.val)
+	// Every caller gets its own copy, so none can modify what the others
+	// received.
+	return c.copyValue(cl.val), nil
+}
+
+// GetOrLoadMany returns the values for keys, calling loader once with all
+// the keys that are missing and storing what it returns. Keys loader leaves
+// out of its result are omitted from the returned map and not cached. If
+// another caller stored a missing key while loader ran, the stored value
+// wins. If loader fails, its error is returned and nothing is stored. Calls
+// are not coalesced with GetOrLoad.
+func (c *LRU[K, V]) GetOrLoadMany(keys []K, loader func(missing []K) (map[K]V, error)) (map[K]V, error) {
+	found := c.GetMany(keys)
+	var missing []K
+	seen := make(map[K]struct{})
+	for _, key := range keys {
+		if _, ok := found[key]; ok {
+			continue
+		}
+		if _, ok := seen[key]; !ok {
+			seen[key] = struct{}{}
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) == 0 {
+		return found, nil
+	}
+
+	loaded, err := loader(missing)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range missing {
+		if val, ok := loaded[key]; ok {
+			found[key], _ = c.getOrPut(key, val)
+		}
+	}
+	return found, nil
+}
+
+// GetOrLoadContext is like GetOrLoad but passes ctx to loader and returns
+// ctx.Err() as soon as ctx is done, even if loader has not returned. Nothing
+// is stored if ctx is done before loader completes. Loader should return
+// promptly once ctx is done, as it keeps running in the background until it
+// does. Calls are not coalesced with other callers, so one caller's
+// cancellation never fails another.
+func (c *LRU[K, V]) GetOrLoadContext(ctx context.Context, key K, loader func(context.Context, K) (V, error)) (V, error) {
+	if val, ok := c.Get(key); ok {
+		return val, nil
+	}
+	var zero V
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+
+	type result struct {
+		val V
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		val, err := loader(ctx, key)
+		done <- result{val, err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	case r := <-done:
+		if r.err != nil {
+			return zero, r.err
+		}
+		actual, _ := c.GetOrPut(key, r.val)
+		return actual, nil
+	}
+}
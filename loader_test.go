@@ -0,0 +1,279 @@
+package lru
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestGetOrLoad verifies hits skip the loader and misses store its result.
+func TestGetOrLoad(t *testing.T) {
+	cache, _ := NewLRU[int, string](2)
+	calls := 0
+	loader := func(k int) (string, error) {
+		calls++
+		return "loaded", nil
+	}
+
+	cache.Put(1, "one")
+	if val, err := cache.GetOrLoad(1, loader); err != nil || val != "one" {
+		t.Errorf("expected cached one, got %v, %v", val, err)
+	}
+	if calls != 0 {
+		t.Errorf("expected loader not to run on hit, ran %d times", calls)
+	}
+
+	if val, err := cache.GetOrLoad(2, loader); err != nil || val != "loaded" {
+		t.Errorf("expected loaded, got %v, %v", val, err)
+	}
+	if calls != 1 {
+		t.Errorf("expected loader to run once on miss, ran %d times", calls)
+	}
+	if val, ok := cache.Peek(2); !ok || val != "loaded" {
+		t.Errorf("expected loaded value to be stored, got %v", val)
+	}
+}
+
+// TestGetOrLoadError verifies loader errors are returned and nothing is stored.
+func TestGetOrLoadError(t *testing.T) {
+	cache, _ := NewLRU[int, string](2)
+	errBackend := errors.New("backend down")
+
+	_, err := cache.GetOrLoad(1, func(k int) (string, error) {
+		return "", errBackend
+	})
+	if !errors.Is(err, errBackend) {
+		t.Errorf("expected backend error, got %v", err)
+	}
+	if cache.Contains(1) {
+		t.Errorf("expected nothing to be stored on loader error")
+	}
+}
+
+// TestGetOrLoadCoalesces verifies concurrent misses share one loader call.
+func TestGetOrLoadCoalesces(t *testing.T) {
+	cache, _ := NewLRU[int, string](2)
+	var calls atomic.Int32
+	release := make(chan struct{})
+	loader := func(k int) (string, error) {
+		calls.Add(1)
+		<-release
+		return "loaded", nil
+	}
+
+	const n = 50
+	wg := sync.WaitGroup{}
+	results := make([]string, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = cache.GetOrLoad(1, loader)
+		}(i)
+	}
+
+	time.Sleep(20 * time.Millisecond) // let the goroutines pile up on the load
+	close(release)
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("expected loader to run once, ran %d times", calls.Load())
+	}
+	for i, r := range results {
+		if r != "loaded" {
+			t.Errorf("caller %d got %q", i, r)
+		}
+	}
+	if len(cache.loads) != 0 {
+		t.Errorf("expected no in-flight loads left, got %d", len(cache.loads))
+	}
+}
+
+// TestGetOrLoadCoalescedError verifies a shared loader error reaches all
+// callers and is cleaned up so the next call retries.
+func TestGetOrLoadCoalescedError(t *testing.T) {
+	cache, _ := NewLRU[int, string](2)
+	errBackend := errors.New("backend down")
+	release := make(chan struct{})
+	var calls atomic.Int32
+
+	wg := sync.WaitGroup{}
+	errs := make([]error, 10)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = cache.GetOrLoad(1, func(k int) (string, error) {
+				calls.Add(1)
+				<-release
+				return "", errBackend
+			})
+		}(i)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	for i, err := range errs {
+		if !errors.Is(err, errBackend) {
+			t.Errorf("caller %d got %v", i, err)
+		}
+	}
+	if len(cache.loads) != 0 {
+		t.Errorf("expected no in-flight loads left, got %d", len(cache.loads))
+	}
+
+	before := calls.Load()
+	val, err := cache.GetOrLoad(1, func(k int) (string, error) {
+		calls.Add(1)
+		return "one", nil
+	})
+	if err != nil || val != "one" || calls.Load() != before+1 {
+		t.Errorf("expected retry after error, got %v, %v", val, err)
+	}
+}
+
+// TestGetOrLoadContext verifies the loader receives the context and its
+// result is stored.
+func TestGetOrLoadContext(t *testing.T) {
+	cache, _ := NewLRU[int, string](2)
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "v")
+
+	val, err := cache.GetOrLoadContext(ctx, 1, func(ctx context.Context, k int) (string, error) {
+		return ctx.Value(ctxKey{}).(string), nil
+	})
+	if err != nil || val != "v" {
+		t.Errorf("expected v, got %v, %v", val, err)
+	}
+	if !cache.Contains(1) {
+		t.Errorf("expected loaded value to be stored")
+	}
+}
+
+// TestGetOrLoadContextCancel verifies cancellation returns the context error
+// and stores nothing.
+func TestGetOrLoadContextCancel(t *testing.T) {
+	cache, _ := NewLRU[int, string](2)
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	_, err := cache.GetOrLoadContext(ctx, 1, func(ctx context.Context, k int) (string, error) {
+		close(started)
+		<-ctx.Done()
+		return "late", nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+
+	time.Sleep(10 * time.Millisecond) // give the loader goroutine time to finish
+	if cache.Contains(1) {
+		t.Errorf("expected nothing to be stored after cancellation")
+	}
+
+	if _, err := cache.GetOrLoadContext(ctx, 2, func(ctx context.Context, k int) (string, error) {
+		t.Errorf("loader should not run with a done context")
+		return "", nil
+	}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled for done context, got %v", err)
+	}
+}
+
+// TestGetOrLoadMany verifies hits skip the loader and misses are loaded in
+// a single call.
+func TestGetOrLoadMany(t *testing.T) {
+	cache, _ := NewLRU[string, int](5)
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+
+	calls := 0
+	loader := func(missing []string) (map[string]int, error) {
+		calls++
+		out := make(map[string]int)
+		for _, k := range missing {
+			if k != "nope" {
+				out[k] = len(k) * 10
+			}
+		}
+		return out, nil
+	}
+
+	got, err := cache.GetOrLoadMany([]string{"a", "b"}, loader)
+	if err != nil || calls != 0 || len(got) != 2 || got["a"] != 1 || got["b"] != 2 {
+		t.Errorf("expected full hit without loader, got %v, %v after %d calls", got, err, calls)
+	}
+
+	var requested []string
+	got, err = cache.GetOrLoadMany([]string{"a", "cc", "nope", "cc"}, func(missing []string) (map[string]int, error) {
+		requested = missing
+		return loader(missing)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 || len(requested) != 2 || requested[0] != "cc" || requested[1] != "nope" {
+		t.Errorf("expected one call for [cc nope], got %d calls for %v", calls, requested)
+	}
+	if len(got) != 2 || got["a"] != 1 || got["cc"] != 20 {
+		t.Errorf("expected a=1 cc=20, got %v", got)
+	}
+	if !cache.Contains("cc") || cache.Contains("nope") {
+		t.Errorf("expected only loaded keys to be cached, got %v", cache.Keys())
+	}
+}
+
+// TestGetOrLoadManyError verifies a failed loader stores nothing.
+func TestGetOrLoadManyError(t *testing.T) {
+	cache, _ := NewLRU[string, int](5)
+	cache.Put("a", 1)
+	errBackend := errors.New("backend down")
+
+	got, err := cache.GetOrLoadMany([]string{"a", "b", "c"}, func(missing []string) (map[string]int, error) {
+		return map[string]int{"b": 2}, errBackend
+	})
+	if !errors.Is(err, errBackend) || got != nil {
+		t.Errorf("expected backend error and nil map, got %v, %v", got, err)
+	}
+	if cache.Len() != 1 || cache.Contains("b") {
+		t.Errorf("expected nothing stored, got %v", cache.Keys())
+	}
+}
+
+// TestEnsureLoaded verifies the loader runs once on a miss and not on a hit.
+func TestEnsureLoaded(t *testing.T) {
+	cache, _ := NewLRU[string, int](2)
+	calls := 0
+	loader := func(k string) (int, error) {
+		calls++
+		return 7, nil
+	}
+
+	if err := cache.EnsureLoaded("a", loader); err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.EnsureLoaded("a", loader); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Errorf("expected loader to run once, got %d", calls)
+	}
+	if v, ok := cache.Peek("a"); !ok || v != 7 {
+		t.Errorf("expected a=7 cached, got %d, %v", v, ok)
+	}
+
+	errBackend := errors.New("backend down")
+	err := cache.EnsureLoaded("b", func(string) (int, error) { return 0, errBackend })
+	if !errors.Is(err, errBackend) || cache.Contains("b") {
+		t.Errorf("expected backend error and nothing stored, got %v", err)
+	}
+}
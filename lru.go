@@ -4,78 +4,401 @@ package lru
 
 import (
 	"container/list"
-	"errors"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // LRU is a thread-safe Least Recently Used cache with O(1) Get and Put.
 type LRU[K comparable, V any] struct {
-	cap     int
-	mu      sync.RWMutex
-	list    *list.List // holds *entry[K,V]
-	idx     map[K]*list.Element
-	onEvict func(key K, value V) // optional eviction callback
+	cap      int
+	mu       sync.RWMutex
+	list     *list.List // holds *entry[K,V]
+	idx      map[K]*list.Element
+	onEvict  func(key K, value V)       // optional eviction callback
+	onExpire func(key K, value V)       // optional expiration callback
+	onInsert func(key K, value V)       // optional callback for new keys
+	onUpdate func(key K, value V)       // optional callback for overwrites
+	onRemove func(K, V, RemoveReason)   // optional callback for every removal
+	ttl      time.Duration              // default TTL for Put, zero means none
+	maxCost  int64                      // total cost budget, zero means unbounded
+	cost     int64                      // total cost of all entries
+	seq      uint64                     // sequence number of the last insert
+	sizer    func(key K, value V) int64 // optional per-entry cost function
+	clock    Clock                      // source of time for expiry
+	janitor  *janitor                   // background expiry sweeper, if running
+	reporter *statsReporter             // background stats callback, if running
+	evictQ   *evictQueue[K, V]          // runs eviction callbacks, if async
+	values   map[any]map[K]struct{}     // keys by value, nil unless indexed
+	policy   Policy                     // eviction order
+
+	onOverflow  func(key K, value V, victim K) (K, bool) // optional overflow handler
+	expireFirst bool                                     // expire stale entries before evicting live ones
+	initSize    int                                      // initial size of the index map, zero to use cap
+	peekTTL     bool                                     // Peek restarts the TTL
+	beforeEvict func(key K, value V) error               // optional hook that can keep a victim
+	resizer     *autoResizer                             // automatic growth, if enabled
+	copier      func(V) V                                // copies values returned by lookups, if set
+	evictBatch  int                                      // items allowed over cap before evicting, if > 0
+	noPromote   bool                                     // reads do not update recency
+	subs        map[chan Event[K, V]]struct{}            // change stream subscribers
+
+	refreshAt  float64            // fraction of TTL after which Get refreshes
+	refresher  func(K) (V, error) // loader for refresh-ahead, nil if disabled
+	refreshing map[K]struct{}     // keys with a refresh in flight
+
+	hits      atomic.Uint64 // Get calls that found a live entry
+	misses    atomic.Uint64 // Get calls that found nothing
+	evictions atomic.Uint64 // capacity-driven evictions
+
+	loadMu sync.Mutex     // guards loads
+	loads  map[K]*call[V] // in-flight GetOrLoad calls by key
 }
 
 type entry[K comparable, V any] struct {
-	key K
-	val V
+	key     K
+	val     V
+	expires time.Time     // zero means the entry never expires
+	ttl     time.Duration // lifetime the entry was stored with, zero if none
+	cost    int64
+	pinned  bool // never chosen for eviction
+
+	accessed time.Time // last Get or Put
+	negative bool      // caches the absence of key; val is the zero value
+	seq      uint64    // insertion sequence number, kept across overwrites
+	version  uint64    // incremented each time val is replaced
 }
 
 // NewLRU creates a new LRU cache with the specified capacity.
 // Returns an error if capacity <= 0.
 func NewLRU[K comparable, V any](capacity int) (*LRU[K, V], error) {
-	if capacity <= 0 {
-		return nil, errors.New("capacity must be greater than 0")
-	}
-	return &LRU[K, V]{
-		cap:  capacity,
-		list: list.New(),
-		idx:  make(map[K]*list.Element, capacity),
-	}, nil
+	return NewLRUWithOptions(WithCapacity[K, V](capacity))
 }
 
 // Get retrieves the value for the given key if present.
 // Moves the accessed item to the front of the cache.
+// Expired entries are removed and reported as absent.
 func (c *LRU[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.get(key)
+}
+
+// GetWithDefault returns the value for the given key like Get, or def if
+// the key is absent or expired.
+func (c *LRU[K, V]) GetWithDefault(key K, def V) V {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if val, ok := c.get(key); ok {
+		return val
+	}
+	return def
+}
+
+// TryGet is like Get but does not wait if the cache is locked by another
+// goroutine. The last result reports whether the lock was acquired; when it
+// is false the lookup was skipped, nothing is counted, and the other
+// results are the zero value and false.
+func (c *LRU[K, V]) TryGet(key K) (val V, ok bool, acquired bool) {
+	if !c.mu.TryLock() {
+		return val, false, false
+	}
+	defer c.mu.Unlock()
+	val, ok = c.get(key)
+	return val, ok, true
+}
+
+// Peek returns the value for the given key without updating its recency.
+// Expired entries are removed and reported as absent. If the cache was
+// created with WithPeekRefreshesTTL, Peek also restarts the entry's TTL.
+func (c *LRU[K, V]) Peek(key K) (V, bool) {
+	if c.peekTTL {
+		return c.peekRefresh(key)
+	}
 	c.mu.RLock()
-	defer c.mu.RUnlock()
-	var zero V
-	if el, ok := c.idx[key]; ok {
-		// Move to front under write lock
+	el, ok := c.idx[key]
+	if ok && c.live(el.Value.(*entry[K, V])) {
+		val := c.read(el.Value.(*entry[K, V]))
 		c.mu.RUnlock()
-		c.mu.Lock()
-		c.list.MoveToFront(el)
-		c.mu.Unlock()
-		c.mu.RLock()
-		return el.Value.(*entry[K, V]).val, true
+		return val, true
+	}
+	c.mu.RUnlock()
+	if ok {
+		c.removeExpired(key)
 	}
+	var zero V
 	return zero, false
 }
 
+// PeekOldest returns the least recently used live entry without updating
+// its recency. Returns ok=false if the cache is empty. The entry may be
+// pinned, in which case RemoveOldest removes a more recent one.
+func (c *LRU[K, V]) PeekOldest() (key K, value V, ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for el := c.list.Back(); el != nil; el = el.Prev() {
+		if kv := el.Value.(*entry[K, V]); c.live(kv) {
+			return kv.key, kv.val, true
+		}
+	}
+	return key, value, false
+}
+
+// PeekNewest returns the most recently used live entry without updating
+// its recency. Returns ok=false if the cache is empty.
+func (c *LRU[K, V]) PeekNewest() (key K, value V, ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for el := c.list.Front(); el != nil; el = el.Next() {
+		if kv := el.Value.(*entry[K, V]); c.live(kv) {
+			return kv.key, kv.val, true
+		}
+	}
+	return key, value, false
+}
+
+// Contains reports whether the key is in the cache without updating its recency.
+func (c *LRU[K, V]) Contains(key K) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	el, ok := c.idx[key]
+	return ok && c.live(el.Value.(*entry[K, V]))
+}
+
 // Put inserts or updates the value for the given key.
 // If capacity is exceeded, evicts the least recently used item.
+// Entries expire after the cache's default TTL, if one is configured.
 func (c *LRU[K, V]) Put(key K, val V) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	c.put(key, val, c.ttl, c.sizeOf(key, val))
+}
+
+// UpdateValue replaces the value for an existing key without updating its
+// recency or expiry. Returns false, without inserting, if the key is absent
+// or stored with PutNegative.
+// If the new value alone exceeds the cost budget, the entry is removed and
+// false is returned.
+func (c *LRU[K, V]) UpdateValue(key K, val V) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.idx[key]
+	if !ok {
+		return false
+	}
+	kv := el.Value.(*entry[K, V])
+	if c.expired(kv) {
+		c.expire(el)
+		return false
+	}
+	if kv.negative {
+		return false
+	}
+	cost := c.replacementCost(kv, val)
+	if c.tooLarge(cost) {
+		c.removeElement(el)
+		c.notifyRemove(key, kv.val, Replaced)
+		return false
+	}
+	old := kv.val
+	c.setValue(kv, val)
+	c.cost += cost - kv.cost
+	kv.cost = cost
+	if c.onUpdate != nil {
+		c.onUpdate(key, val)
+	}
+	c.publish(EventUpdate, key, val)
+	c.notifyRemove(key, old, Replaced)
+	c.evictOverflow(el)
+	return true
+}
+
+// Add inserts or updates the value for the given key like Put, and reports
+// whether doing so evicted another entry.
+func (c *LRU[K, V]) Add(key K, val V) (evicted bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n, _ := c.put(key, val, c.ttl, c.sizeOf(key, val))
+	return n > 0
+}
+
+// GetOrPut returns the existing value for the key if present and promotes it.
+// Otherwise it stores and returns the given value. The loaded result is true
+// if the value was loaded, false if stored.
+func (c *LRU[K, V]) GetOrPut(key K, val V) (actual V, loaded bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.getOrPut(key, val)
+}
+
+// getOrPut implements GetOrPut. Caller must hold the write lock.
+func (c *LRU[K, V]) getOrPut(key K, val V) (actual V, loaded bool) {
 	if el, ok := c.idx[key]; ok {
-		el.Value.(*entry[K, V]).val = val
-		c.list.MoveToFront(el)
-		return
+		kv := el.Value.(*entry[K, V])
+		if c.live(kv) {
+			c.touchRead(el)
+			return c.read(kv), true
+		}
+		if c.expired(kv) {
+			c.expire(el)
+		}
 	}
-	el := c.list.PushFront(&entry[K, V]{key: key, val: val})
-	c.idx[key] = el
-	if c.list.Len() > c.cap {
-		tail := c.list.Back()
-		if tail != nil {
-			c.list.Remove(tail)
-			kv := tail.Value.(*entry[K, V])
-			delete(c.idx, kv.key)
-			if c.onEvict != nil {
-				c.onEvict(kv.key, kv.val)
-			}
+	c.put(key, val, c.ttl, c.sizeOf(key, val))
+	return c.readStored(key, val), false
+}
+
+// PutIfAbsent inserts the value for the given key only if it is not
+// already present. An existing key is left untouched, without updating its
+// recency. Returns true if the value was stored.
+func (c *LRU[K, V]) PutIfAbsent(key K, val V) (stored bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.idx[key]; ok {
+		kv := el.Value.(*entry[K, V])
+		if c.live(kv) {
+			return false
+		}
+		if c.expired(kv) {
+			c.expire(el)
 		}
 	}
+	_, err := c.put(key, val, c.ttl, c.sizeOf(key, val))
+	return err == nil
+}
+
+// Remove deletes the entry for the given key.
+// Returns true if the key was present. The eviction callback is not called.
+func (c *LRU[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.idx[key]; ok {
+		kv := c.removeElement(el)
+		c.notifyRemove(kv.key, kv.val, Manual)
+		return true
+	}
+	return false
+}
+
+// Demote moves the entry for key to the back of the cache, making it the
+// least recently used, without otherwise changing it. With LRUPolicy and
+// FIFOPolicy it becomes the next entry to be evicted; policies that keep
+// their own order, such as LFUPolicy, may still choose another victim.
+// Returns false if the key is not present.
+func (c *LRU[K, V]) Demote(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.idx[key]
+	if !ok {
+		return false
+	}
+	if c.expired(el.Value.(*entry[K, V])) {
+		c.expire(el)
+		return false
+	}
+	c.list.MoveToBack(el)
+	return true
+}
+
+// GetAndRemove retrieves the value for the given key and removes it in one
+// step, so concurrent callers never both receive the same entry. It counts
+// as a hit or miss like Get. The eviction callback is not called.
+func (c *LRU[K, V]) GetAndRemove(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	val, ok := c.get(key)
+	if ok {
+		kv := c.removeElement(c.idx[key])
+		c.notifyRemove(kv.key, kv.val, Manual)
+	}
+	return val, ok
+}
+
+// RemoveOldest evicts the least recently used live entry that is not
+// pinned, and returns it, calling the eviction callback. Expired entries
+// passed over are removed as expired, and negative and pinned entries are
+// skipped. Returns ok=false if there is no such entry.
+func (c *LRU[K, V]) RemoveOldest() (key K, value V, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for el := c.list.Back(); el != nil; {
+		prev := el.Prev()
+		kv := el.Value.(*entry[K, V])
+		switch {
+		case c.expired(kv):
+			c.expire(el)
+		case !kv.negative && !kv.pinned:
+			c.evict(el)
+			return kv.key, kv.val, true
+		}
+		el = prev
+	}
+	return key, value, false
+}
+
+// Clear removes all entries from the cache.
+// The capacity and eviction callback are kept; the callback is not called
+// for the cleared entries.
+func (c *LRU[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.clear()
+}
+
+// Purge removes all entries from the cache, calling the eviction callback
+// for each from least to most recently used. Use Clear to skip the callback.
+func (c *LRU[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.purge()
+}
+
+// purge implements Purge. Caller must hold the write lock.
+func (c *LRU[K, V]) purge() {
+	if c.onEvict != nil || c.onRemove != nil || len(c.subs) > 0 {
+		for el := c.list.Back(); el != nil; el = el.Prev() {
+			kv := el.Value.(*entry[K, V])
+			c.notifyEvict(kv.key, kv.val)
+			c.notifyRemove(kv.key, kv.val, Manual)
+		}
+	}
+	c.clear()
+}
+
+// Resize changes the capacity of the cache.
+// If the new capacity is smaller, least recently used items are evicted
+// until the cache fits. Returns an error if newCap <= 0.
+func (c *LRU[K, V]) Resize(newCap int) error {
+	if newCap <= 0 {
+		return ErrInvalidCapacity
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cap = newCap
+	c.evictToLimits(nil)
+	return nil
+}
+
+// Grow increases the capacity of the cache by delta. Returns an error if
+// the resulting capacity would be <= 0 or the capacity is unlimited.
+func (c *LRU[K, V]) Grow(delta int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cap == NoCapacityLimit {
+		return ErrUnlimitedCapacity
+	}
+	if c.cap+delta <= 0 {
+		return ErrInvalidCapacity
+	}
+	c.cap += delta
+	c.evictToLimits(nil)
+	return nil
+}
+
+// Shrink decreases the capacity of the cache by delta, evicting least
+// recently used items until the cache fits. Returns an error if the
+// resulting capacity would be <= 0 or the capacity is unlimited.
+func (c *LRU[K, V]) Shrink(delta int) error {
+	return c.Grow(-delta)
 }
 
 // Len returns the current number of items in the cache.
@@ -85,9 +408,388 @@ func (c *LRU[K, V]) Len() int {
 	return c.list.Len()
 }
 
+// Cap returns the configured capacity of the cache, or NoCapacityLimit if
+// the number of items is unlimited.
+func (c *LRU[K, V]) Cap() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.cap
+}
+
+// Keys returns a copy of the keys ordered from least to most recently used.
+// Negative entries stored with PutNegative are left out.
+func (c *LRU[K, V]) Keys() []K {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	keys := make([]K, 0, c.list.Len())
+	for el := c.list.Back(); el != nil; el = el.Prev() {
+		if kv := el.Value.(*entry[K, V]); !kv.negative {
+			keys = append(keys, kv.key)
+		}
+	}
+	return keys
+}
+
+// Values returns a copy of the values ordered from least to most recently
+// used, in the same order as Keys.
+func (c *LRU[K, V]) Values() []V {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	values := make([]V, 0, c.list.Len())
+	for el := c.list.Back(); el != nil; el = el.Prev() {
+		if kv := el.Value.(*entry[K, V]); !kv.negative {
+			values = append(values, kv.val)
+		}
+	}
+	return values
+}
+
+// Range calls f for each live entry from most to least recently used,
+// stopping early if f returns false. The read lock is held for the whole
+// iteration, so f must not call back into the cache, and must not retain
+// the key or value beyond the call.
+func (c *LRU[K, V]) Range(f func(key K, value V) bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for el := c.list.Front(); el != nil; el = el.Next() {
+		kv := el.Value.(*entry[K, V])
+		if !c.live(kv) {
+			continue
+		}
+		if !f(kv.key, kv.val) {
+			return
+		}
+	}
+}
+
 // SetEvictionCallback sets the callback to be called when an item is evicted.
 func (c *LRU[K, V]) SetEvictionCallback(fn func(key K, value V)) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	c.onEvict = fn
 }
+
+// SetInsertCallback sets the callback to be called when a key that was not
+// present is stored.
+func (c *LRU[K, V]) SetInsertCallback(fn func(key K, value V)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onInsert = fn
+}
+
+// SetUpdateCallback sets the callback to be called when the value of a key
+// that is already present is replaced.
+func (c *LRU[K, V]) SetUpdateCallback(fn func(key K, value V)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onUpdate = fn
+}
+
+// get looks up key, promoting it and counting the hit or miss.
+// Caller must hold the write lock.
+func (c *LRU[K, V]) get(key K) (V, bool) {
+	val, state := c.getState(key)
+	return val, state == Hit
+}
+
+// getState looks up key, promoting it and counting the hit or miss. A
+// negative entry is promoted but counted as a miss. Caller must hold the
+// write lock.
+func (c *LRU[K, V]) getState(key K) (V, State) {
+	var zero V
+	el, ok := c.idx[key]
+	if !ok {
+		c.misses.Add(1)
+		c.sampleLookup(false)
+		return zero, Miss
+	}
+	if c.expired(el.Value.(*entry[K, V])) {
+		c.expire(el)
+		c.misses.Add(1)
+		c.sampleLookup(false)
+		return zero, Miss
+	}
+	if el.Value.(*entry[K, V]).negative {
+		c.touchRead(el)
+		c.misses.Add(1)
+		c.sampleLookup(false)
+		return zero, NegativeHit
+	}
+	c.touchRead(el)
+	c.hits.Add(1)
+	c.sampleLookup(true)
+	kv := el.Value.(*entry[K, V])
+	c.maybeRefresh(kv)
+	return c.read(kv), Hit
+}
+
+// read returns the value of kv to hand to a caller, copied if the cache
+// was created with WithCopyOnRead. Caller must hold the lock.
+func (c *LRU[K, V]) read(kv *entry[K, V]) V {
+	return c.copyValue(kv.val)
+}
+
+// readStored returns the value just stored for key as read would, or val if
+// it was not stored. Caller must hold the lock.
+func (c *LRU[K, V]) readStored(key K, val V) V {
+	if el, ok := c.idx[key]; ok {
+		return c.read(el.Value.(*entry[K, V]))
+	}
+	return val
+}
+
+// copyValue returns val, copied if the cache was created with
+// WithCopyOnRead. The copier is fixed at construction, so no lock is needed.
+func (c *LRU[K, V]) copyValue(val V) V {
+	if c.copier != nil {
+		return c.copier(val)
+	}
+	return val
+}
+
+// put inserts or updates key with the given TTL and cost, then evicts
+// until the cache fits, returning the number of entries evicted. An entry
+// that could never fit within maxCost is not stored, any existing entry for
+// key is removed and ErrItemTooLarge is returned. ErrCacheFull is returned
+// if the overflow handler rejects a new key. Caller must hold the write lock.
+func (c *LRU[K, V]) put(key K, val V, ttl time.Duration, cost int64) (evicted int, err error) {
+	return c.store(key, val, ttl, cost, false)
+}
+
+// store implements put and PutNegative. A negative entry is stored without
+// calling the insert or update callback or publishing an event, since it
+// holds no value. Caller must hold the write lock.
+func (c *LRU[K, V]) store(key K, val V, ttl time.Duration, cost int64, negative bool) (evicted int, err error) {
+	if c.tooLarge(cost) {
+		if el, ok := c.idx[key]; ok {
+			kv := c.removeElement(el)
+			c.notifyRemove(key, kv.val, Replaced)
+		}
+		return 0, ErrItemTooLarge
+	}
+	if ttl < 0 {
+		ttl = 0
+	}
+	expires := c.deadline(ttl)
+	el, ok := c.idx[key]
+	if ok {
+		kv := el.Value.(*entry[K, V])
+		old := kv.val
+		c.setValue(kv, val)
+		kv.negative = negative
+		kv.expires = expires
+		kv.ttl = ttl
+		c.cost += cost - kv.cost
+		kv.cost = cost
+		c.touch(el)
+		if !negative {
+			if c.onUpdate != nil {
+				c.onUpdate(key, val)
+			}
+			c.publish(EventUpdate, key, val)
+		}
+		c.notifyRemove(key, old, Replaced)
+	} else {
+		if c.onOverflow != nil {
+			n, ok := c.makeRoom(key, val, cost)
+			if !ok {
+				return n, ErrCacheFull
+			}
+			evicted = n
+		}
+		c.seq++
+		el = c.list.PushFront(&entry[K, V]{key: key, val: val, expires: expires, ttl: ttl, cost: cost, seq: c.seq, accessed: c.now(), negative: negative})
+		c.idx[key] = el
+		c.cost += cost
+		c.indexValue(key, val)
+		c.policy.OnInsert(c.list, el)
+		if !negative {
+			if c.onInsert != nil {
+				c.onInsert(key, val)
+			}
+			c.publish(EventInsert, key, val)
+		}
+	}
+	return evicted + c.evictOverflow(el), nil
+}
+
+// makeRoom asks the overflow handler which entries to evict until a new
+// entry of the given cost fits. It returns the number evicted and false if
+// the handler rejected the new entry. Caller must hold the write lock.
+func (c *LRU[K, V]) makeRoom(key K, val V, cost int64) (int, bool) {
+	n := 0
+	full := func() bool {
+		return c.cap != NoCapacityLimit && c.list.Len() >= c.cap || c.maxCost > 0 && c.cost+cost > c.maxCost
+	}
+	if c.expireFirst && full() {
+		c.expireAll(nil)
+	}
+	for full() {
+		el := c.victim(nil)
+		if el == nil {
+			break
+		}
+		evict, ok := c.onOverflow(key, val, el.Value.(*entry[K, V]).key)
+		if !ok {
+			return n, false
+		}
+		if chosen, found := c.idx[evict]; found && !chosen.Value.(*entry[K, V]).pinned {
+			el = chosen
+		}
+		if !c.tryEvict(el) {
+			break
+		}
+		n++
+	}
+	return n, true
+}
+
+// clear drops all entries. Caller must hold the write lock.
+func (c *LRU[K, V]) clear() {
+	c.list.Init()
+	c.idx = make(map[K]*list.Element, c.sizeHint())
+	c.cost = 0
+	if c.values != nil {
+		c.values = make(map[any]map[K]struct{})
+	}
+	c.policy.Reset()
+}
+
+// removeElement unlinks el from the list and the index and tells the
+// policy it was removed. Caller must hold the write lock.
+func (c *LRU[K, V]) removeElement(el *list.Element) *entry[K, V] {
+	kv := c.unlink(el)
+	c.policy.OnRemove(el)
+	return kv
+}
+
+// unlink removes el from the list and the index without telling the policy.
+// Caller must hold the write lock.
+func (c *LRU[K, V]) unlink(el *list.Element) *entry[K, V] {
+	c.list.Remove(el)
+	kv := el.Value.(*entry[K, V])
+	delete(c.idx, kv.key)
+	c.cost -= kv.cost
+	c.unindexValue(kv.key, kv.val)
+	return kv
+}
+
+// touch records an access to el with its timestamp and the policy.
+// Caller must hold the write lock.
+func (c *LRU[K, V]) touch(el *list.Element) {
+	el.Value.(*entry[K, V]).accessed = c.now()
+	c.policy.OnAccess(c.list, el)
+}
+
+// touchRead records a read of el, like touch unless read promotion is
+// disabled, in which case only the access time is updated. Caller must hold
+// the write lock.
+func (c *LRU[K, V]) touchRead(el *list.Element) {
+	if c.noPromote {
+		el.Value.(*entry[K, V]).accessed = c.now()
+		return
+	}
+	c.touch(el)
+}
+
+// victim returns the element the policy would evict next, never choosing
+// keep or a pinned entry. Returns nil if there is no candidate. Caller must
+// hold the lock.
+func (c *LRU[K, V]) victim(keep *list.Element) *list.Element {
+	el := c.policy.Victim(c.list, nil)
+	for el != nil && (el == keep || el.Value.(*entry[K, V]).pinned) {
+		el = c.policy.Victim(c.list, el)
+	}
+	return el
+}
+
+// evictOverflow evicts items after a write until the cache fits its
+// capacity and cost budget, never evicting keep, and returns the number
+// evicted. Eviction is skipped while deferEviction allows it.
+// Caller must hold the write lock.
+func (c *LRU[K, V]) evictOverflow(keep *list.Element) int {
+	if c.deferEviction() {
+		return 0
+	}
+	return c.evictToLimits(keep)
+}
+
+// evictToLimits evicts items until the cache fits its capacity and cost
+// budget, never evicting keep, and returns the number evicted. Unlike
+// evictOverflow it ignores WithEvictionBatch, so a change of capacity takes
+// effect at once. Caller must hold the write lock.
+func (c *LRU[K, V]) evictToLimits(keep *list.Element) int {
+	n := 0
+	if c.expireFirst && c.overLimits() {
+		c.expireAll(keep)
+	}
+	for c.overLimits() {
+		el := c.victim(keep)
+		if el == nil || !c.tryEvict(el) {
+			break
+		}
+		n++
+	}
+	return n
+}
+
+// deferEviction reports whether eviction can wait because the cache is
+// still within the allowance set by WithEvictionBatch and its cost budget.
+// Caller must hold the lock.
+func (c *LRU[K, V]) deferEviction() bool {
+	return c.evictBatch > 0 && c.cap != NoCapacityLimit &&
+		c.list.Len() < c.cap+c.evictBatch &&
+		!(c.maxCost > 0 && c.cost > c.maxCost)
+}
+
+// overLimits reports whether the cache exceeds its capacity or cost budget.
+// Caller must hold the lock.
+func (c *LRU[K, V]) overLimits() bool {
+	return c.overCapacity() || c.maxCost > 0 && c.cost > c.maxCost
+}
+
+// overCapacity reports whether the cache holds more items than its capacity.
+// Caller must hold the lock.
+func (c *LRU[K, V]) overCapacity() bool {
+	return c.cap != NoCapacityLimit && c.list.Len() > c.cap
+}
+
+// sizeHint returns the initial size for the index map.
+func (c *LRU[K, V]) sizeHint() int {
+	if c.initSize > 0 {
+		return c.initSize
+	}
+	if c.cap == NoCapacityLimit {
+		return 0
+	}
+	return c.cap
+}
+
+// tryEvict evicts el unless the before-evict hook fails for it, and
+// reports whether el was evicted. Caller must hold the write lock.
+func (c *LRU[K, V]) tryEvict(el *list.Element) bool {
+	if c.beforeEvict != nil {
+		kv := el.Value.(*entry[K, V])
+		if err := c.beforeEvict(kv.key, kv.val); err != nil {
+			return false
+		}
+	}
+	c.evict(el)
+	return true
+}
+
+// evict removes el, counts it as an eviction and notifies the eviction
+// callback. A policy implementing PolicyEvicter is told through OnEvict
+// instead of OnRemove. Caller must hold the write lock.
+func (c *LRU[K, V]) evict(el *list.Element) *entry[K, V] {
+	kv := c.unlink(el)
+	if p, ok := c.policy.(PolicyEvicter); ok {
+		p.OnEvict(el)
+	} else {
+		c.policy.OnRemove(el)
+	}
+	c.evictions.Add(1)
+	c.notifyEvict(kv.key, kv.val)
+	c.notifyRemove(kv.key, kv.val, Evicted)
+	return kv
+}
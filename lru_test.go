@@ -65,6 +65,28 @@ func TestConcurrency(t *testing.T) {
 	if cache.Len() > 100 {
 		t.Errorf("cache size exceeded capacity: got %d", cache.Len())
 	}
+
+	// Hammer a small key set so Get promotions race with Put evictions.
+	small, _ := NewLRU[int, int](4)
+	for g := 0; g < 16; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				k := (g + i) % 8
+				small.Put(k, i)
+				if v, ok := small.Get(k); ok && v < 0 {
+					t.Errorf("unexpected value %d for key %d", v, k)
+				}
+			}
+		}(g)
+	}
+
+	wg.Wait()
+
+	if small.Len() > 4 {
+		t.Errorf("cache size exceeded capacity: got %d", small.Len())
+	}
 }
 
 // TestOverwrite verifies that overwriting an existing key updates the value.
@@ -112,3 +134,652 @@ func TestZeroCapacity(t *testing.T) {
 		t.Errorf("expected error for zero capacity cache")
 	}
 }
+
+// TestRemove verifies removing present and absent keys.
+func TestRemove(t *testing.T) {
+	cache, _ := NewLRU[int, string](2)
+	evicted := false
+	cache.SetEvictionCallback(func(k int, v string) {
+		evicted = true
+	})
+
+	cache.Put(1, "one")
+	cache.Put(2, "two")
+
+	if !cache.Remove(1) {
+		t.Errorf("expected Remove(1) to report true")
+	}
+	if cache.Len() != 1 {
+		t.Errorf("expected len 1 after remove, got %d", cache.Len())
+	}
+	if _, ok := cache.Get(1); ok {
+		t.Errorf("expected key 1 to be removed")
+	}
+	if cache.Remove(3) {
+		t.Errorf("expected Remove(3) to report false")
+	}
+	if evicted {
+		t.Errorf("eviction callback should not fire on Remove")
+	}
+
+	cache.Put(1, "uno") // re-add after removal
+	if val, ok := cache.Get(1); !ok || val != "uno" {
+		t.Errorf("expected uno, got %v", val)
+	}
+	if cache.Len() != 2 {
+		t.Errorf("expected len 2, got %d", cache.Len())
+	}
+}
+
+// TestPeek verifies Peek returns values without promoting them.
+func TestPeek(t *testing.T) {
+	cache, _ := NewLRU[int, string](2)
+
+	cache.Put(1, "one")
+	cache.Put(2, "two")
+
+	if val, ok := cache.Peek(1); !ok || val != "one" {
+		t.Errorf("expected one, got %v", val)
+	}
+	if _, ok := cache.Peek(3); ok {
+		t.Errorf("expected miss for key 3")
+	}
+
+	cache.Put(3, "three") // key 1 is still least recently used
+
+	if _, ok := cache.Peek(1); ok {
+		t.Errorf("expected peeked key 1 to be evicted")
+	}
+	if _, ok := cache.Peek(2); !ok {
+		t.Errorf("expected key 2 to survive")
+	}
+}
+
+// TestContains verifies Contains reports membership without reordering.
+func TestContains(t *testing.T) {
+	cache, _ := NewLRU[int, string](2)
+
+	cache.Put(1, "one")
+	cache.Put(2, "two")
+
+	if !cache.Contains(1) {
+		t.Errorf("expected cache to contain key 1")
+	}
+	if cache.Contains(3) {
+		t.Errorf("expected cache not to contain key 3")
+	}
+
+	cache.Put(3, "three") // key 1 is still least recently used
+
+	if cache.Contains(1) {
+		t.Errorf("expected key 1 to be evicted")
+	}
+	if !cache.Contains(2) || !cache.Contains(3) {
+		t.Errorf("expected keys 2 and 3 to remain")
+	}
+}
+
+// TestKeys verifies Keys returns keys from least to most recently used.
+func TestKeys(t *testing.T) {
+	cache, _ := NewLRU[int, string](4)
+
+	cache.Put(1, "one")
+	cache.Put(2, "two")
+	cache.Put(3, "three")
+	cache.Put(4, "four")
+	cache.Get(2)
+	cache.Get(1)
+
+	want := []int{3, 4, 2, 1}
+	got := cache.Keys()
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+
+	got[0] = 99 // mutating the copy must not affect the cache
+	if !cache.Contains(3) || cache.Contains(99) {
+		t.Errorf("Keys should return a copy")
+	}
+}
+
+// TestClear verifies Clear empties the cache and leaves it usable.
+func TestClear(t *testing.T) {
+	cache, _ := NewLRU[int, string](2)
+	evicted := 0
+	cache.SetEvictionCallback(func(k int, v string) {
+		evicted++
+	})
+
+	cache.Put(1, "one")
+	cache.Put(2, "two")
+	cache.Clear()
+
+	if cache.Len() != 0 {
+		t.Errorf("expected empty cache, got len %d", cache.Len())
+	}
+	if evicted != 0 {
+		t.Errorf("eviction callback should not fire on Clear")
+	}
+	if cache.Contains(1) {
+		t.Errorf("expected key 1 to be cleared")
+	}
+
+	cache.Put(3, "three")
+	cache.Put(4, "four")
+	cache.Put(5, "five") // capacity and callback are kept
+
+	if cache.Len() != 2 {
+		t.Errorf("expected len 2, got %d", cache.Len())
+	}
+	if evicted != 1 {
+		t.Errorf("expected one eviction after Clear, got %d", evicted)
+	}
+}
+
+// TestResize verifies growing and shrinking the cache at runtime.
+func TestResize(t *testing.T) {
+	cache, _ := NewLRU[int, string](2)
+	var evicted []int
+	cache.SetEvictionCallback(func(k int, v string) {
+		evicted = append(evicted, k)
+	})
+
+	cache.Put(1, "one")
+	cache.Put(2, "two")
+
+	if err := cache.Resize(4); err != nil {
+		t.Fatal(err)
+	}
+	cache.Put(3, "three")
+	cache.Put(4, "four")
+	if cache.Len() != 4 || len(evicted) != 0 {
+		t.Errorf("expected 4 entries and no evictions, got %d and %v", cache.Len(), evicted)
+	}
+
+	cache.Get(1)
+	if err := cache.Resize(2); err != nil {
+		t.Fatal(err)
+	}
+	if cache.Len() != 2 {
+		t.Errorf("expected len 2 after shrink, got %d", cache.Len())
+	}
+	if len(evicted) != 2 || evicted[0] != 2 || evicted[1] != 3 {
+		t.Errorf("expected keys 2 and 3 to be evicted, got %v", evicted)
+	}
+	if !cache.Contains(1) || !cache.Contains(4) {
+		t.Errorf("expected keys 1 and 4 to survive")
+	}
+
+	if err := cache.Resize(0); err == nil {
+		t.Errorf("expected error for zero capacity")
+	}
+	if err := cache.Resize(-1); err == nil {
+		t.Errorf("expected error for negative capacity")
+	}
+	if cache.Len() != 2 {
+		t.Errorf("invalid resize should not change the cache")
+	}
+}
+
+// TestCap verifies Cap reports the configured capacity.
+func TestCap(t *testing.T) {
+	cache, _ := NewLRU[int, string](3)
+
+	if cache.Cap() != 3 {
+		t.Errorf("expected cap 3, got %d", cache.Cap())
+	}
+	cache.Resize(5)
+	if cache.Cap() != 5 {
+		t.Errorf("expected cap 5 after resize, got %d", cache.Cap())
+	}
+}
+
+// TestGetOrPut verifies GetOrPut loads existing values and stores missing ones.
+func TestGetOrPut(t *testing.T) {
+	cache, _ := NewLRU[int, string](2)
+
+	if val, loaded := cache.GetOrPut(1, "one"); loaded || val != "one" {
+		t.Errorf("expected one to be stored, got %v loaded=%v", val, loaded)
+	}
+	if val, loaded := cache.GetOrPut(1, "uno"); !loaded || val != "one" {
+		t.Errorf("expected one to be loaded, got %v loaded=%v", val, loaded)
+	}
+
+	cache.Put(2, "two")
+	cache.GetOrPut(1, "uno") // promotes key 1
+	cache.Put(3, "three")
+
+	if _, ok := cache.Get(2); ok {
+		t.Errorf("expected key 2 to be evicted")
+	}
+}
+
+// TestGetOrPutConcurrent verifies only one concurrent GetOrPut wins.
+func TestGetOrPutConcurrent(t *testing.T) {
+	cache, _ := NewLRU[int, int](2)
+	wg := sync.WaitGroup{}
+	var mu sync.Mutex
+	stored := 0
+	seen := make(map[int]bool)
+
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			actual, loaded := cache.GetOrPut(1, i)
+			mu.Lock()
+			defer mu.Unlock()
+			if !loaded {
+				stored++
+			}
+			seen[actual] = true
+		}(i)
+	}
+
+	wg.Wait()
+
+	if stored != 1 {
+		t.Errorf("expected exactly one store, got %d", stored)
+	}
+	if len(seen) != 1 {
+		t.Errorf("expected all callers to see one value, got %v", seen)
+	}
+}
+
+// TestRange verifies Range visits entries from most to least recently used.
+func TestRange(t *testing.T) {
+	cache, _ := NewLRU[int, string](3)
+	cache.Put(1, "one")
+	cache.Put(2, "two")
+	cache.Put(3, "three")
+	cache.Get(1)
+
+	var visited []int
+	cache.Range(func(k int, v string) bool {
+		visited = append(visited, k)
+		return true
+	})
+	if len(visited) != 3 || visited[0] != 1 || visited[1] != 3 || visited[2] != 2 {
+		t.Errorf("expected [1 3 2], got %v", visited)
+	}
+
+	visited = nil
+	cache.Range(func(k int, v string) bool {
+		visited = append(visited, k)
+		return len(visited) < 2
+	})
+	if len(visited) != 2 || visited[0] != 1 || visited[1] != 3 {
+		t.Errorf("expected early stop after [1 3], got %v", visited)
+	}
+}
+
+// TestPeekOldestNewest verifies the ends of the recency order.
+func TestPeekOldestNewest(t *testing.T) {
+	cache, _ := NewLRU[int, string](3)
+
+	if _, _, ok := cache.PeekOldest(); ok {
+		t.Errorf("expected PeekOldest on empty cache to fail")
+	}
+	if _, _, ok := cache.PeekNewest(); ok {
+		t.Errorf("expected PeekNewest on empty cache to fail")
+	}
+
+	cache.Put(1, "one")
+	cache.Put(2, "two")
+	cache.Put(3, "three")
+	cache.Get(1)
+
+	if k, v, ok := cache.PeekOldest(); !ok || k != 2 || v != "two" {
+		t.Errorf("expected oldest 2, got %d %v", k, v)
+	}
+	if k, v, ok := cache.PeekNewest(); !ok || k != 1 || v != "one" {
+		t.Errorf("expected newest 1, got %d %v", k, v)
+	}
+
+	cache.Put(4, "four") // peeking must not have promoted key 2
+	if cache.Contains(2) {
+		t.Errorf("expected key 2 to be evicted")
+	}
+}
+
+// TestRemoveOldest verifies the least recently used entry is evicted.
+func TestRemoveOldest(t *testing.T) {
+	cache, _ := NewLRU[int, string](3)
+	var evicted []int
+	cache.SetEvictionCallback(func(k int, v string) {
+		evicted = append(evicted, k)
+	})
+
+	if _, _, ok := cache.RemoveOldest(); ok {
+		t.Errorf("expected RemoveOldest on empty cache to fail")
+	}
+
+	cache.Put(1, "one")
+	cache.Put(2, "two")
+	cache.Get(1)
+
+	k, v, ok := cache.RemoveOldest()
+	if !ok || k != 2 || v != "two" {
+		t.Errorf("expected to remove 2, got %d %v", k, v)
+	}
+	if len(evicted) != 1 || evicted[0] != 2 {
+		t.Errorf("expected eviction callback for 2, got %v", evicted)
+	}
+	if cache.Len() != 1 {
+		t.Errorf("expected len 1, got %d", cache.Len())
+	}
+	if cache.Stats().Evictions != 1 {
+		t.Errorf("expected RemoveOldest to count as an eviction")
+	}
+}
+
+// TestUpdateValue verifies values change without affecting eviction order.
+func TestUpdateValue(t *testing.T) {
+	cache, _ := NewLRU[int, string](2)
+
+	if cache.UpdateValue(1, "one") {
+		t.Errorf("expected UpdateValue on absent key to fail")
+	}
+	if cache.Contains(1) {
+		t.Errorf("UpdateValue must not insert")
+	}
+
+	cache.Put(1, "one")
+	cache.Put(2, "two")
+	if !cache.UpdateValue(1, "uno") {
+		t.Errorf("expected UpdateValue on present key to succeed")
+	}
+	if val, _ := cache.Peek(1); val != "uno" {
+		t.Errorf("expected uno, got %v", val)
+	}
+
+	cache.Put(3, "three") // key 1 is still least recently used
+	if cache.Contains(1) {
+		t.Errorf("expected updated key 1 to be evicted")
+	}
+	if !cache.Contains(2) {
+		t.Errorf("expected key 2 to survive")
+	}
+}
+
+// TestPutIfAbsent verifies only missing keys are stored and no promotion occurs.
+func TestPutIfAbsent(t *testing.T) {
+	cache, _ := NewLRU[int, string](2)
+
+	if !cache.PutIfAbsent(1, "one") {
+		t.Errorf("expected new key to be stored")
+	}
+	cache.Put(2, "two")
+	if cache.PutIfAbsent(1, "uno") {
+		t.Errorf("expected existing key not to be stored")
+	}
+	if val, _ := cache.Peek(1); val != "one" {
+		t.Errorf("expected one, got %v", val)
+	}
+
+	cache.Put(3, "three") // no-op must not have promoted key 1
+	if cache.Contains(1) {
+		t.Errorf("expected key 1 to be evicted")
+	}
+}
+
+// TestAdd verifies Add reports evictions once capacity is exceeded.
+func TestAdd(t *testing.T) {
+	cache, _ := NewLRU[int, string](2)
+
+	if cache.Add(1, "one") {
+		t.Errorf("expected no eviction for key 1")
+	}
+	if cache.Add(2, "two") {
+		t.Errorf("expected no eviction for key 2")
+	}
+	if cache.Add(2, "dos") {
+		t.Errorf("expected no eviction when overwriting")
+	}
+	if !cache.Add(3, "three") {
+		t.Errorf("expected eviction for key 3")
+	}
+	if cache.Contains(1) {
+		t.Errorf("expected key 1 to be evicted")
+	}
+}
+
+// TestInsertUpdateCallbacks verifies new keys and overwrites fire separately.
+func TestInsertUpdateCallbacks(t *testing.T) {
+	cache, _ := NewLRU[int, string](2)
+	inserted := make(map[int]int)
+	var updated []string
+	cache.SetInsertCallback(func(k int, v string) {
+		inserted[k]++
+	})
+	cache.SetUpdateCallback(func(k int, v string) {
+		updated = append(updated, v)
+	})
+
+	cache.Put(1, "one")
+	cache.Put(1, "uno")
+	cache.Put(2, "two")
+	cache.PutIfAbsent(2, "dos") // no-op
+	cache.UpdateValue(2, "deux")
+	cache.Put(3, "three") // evicts 1
+	cache.Put(1, "one")   // new again after eviction
+
+	if len(inserted) != 3 || inserted[1] != 2 || inserted[2] != 1 || inserted[3] != 1 {
+		t.Errorf("expected inserts {1:2 2:1 3:1}, got %v", inserted)
+	}
+	if len(updated) != 2 || updated[0] != "uno" || updated[1] != "deux" {
+		t.Errorf("expected updates [uno deux], got %v", updated)
+	}
+}
+
+// TestPurge verifies Purge calls the eviction callback for every entry.
+func TestPurge(t *testing.T) {
+	cache, _ := NewLRU[int, string](4)
+	var purged []int
+	cache.SetEvictionCallback(func(k int, v string) {
+		purged = append(purged, k)
+	})
+
+	cache.Put(1, "one")
+	cache.Put(2, "two")
+	cache.Put(3, "three")
+	n := cache.Len()
+	cache.Purge()
+
+	if len(purged) != n {
+		t.Errorf("expected %d callbacks, got %d", n, len(purged))
+	}
+	if purged[0] != 1 || purged[2] != 3 {
+		t.Errorf("expected purge from least recently used, got %v", purged)
+	}
+	if cache.Len() != 0 {
+		t.Errorf("expected empty cache, got len %d", cache.Len())
+	}
+
+	cache.Put(4, "four")
+	if !cache.Contains(4) {
+		t.Errorf("expected cache to be usable after Purge")
+	}
+}
+
+// TestGetAndRemove verifies the entry is returned and removed without
+// calling the eviction callback.
+func TestGetAndRemove(t *testing.T) {
+	cache, _ := NewLRU[string, int](2)
+	evicted := false
+	cache.SetEvictionCallback(func(string, int) { evicted = true })
+	cache.Put("a", 1)
+
+	if v, ok := cache.GetAndRemove("a"); !ok || v != 1 {
+		t.Errorf("expected 1, true, got %d, %v", v, ok)
+	}
+	if cache.Contains("a") || cache.Len() != 0 {
+		t.Error("expected a to be removed")
+	}
+	if _, ok := cache.GetAndRemove("a"); ok {
+		t.Error("expected second take to fail")
+	}
+	if evicted {
+		t.Error("expected eviction callback not to be called")
+	}
+}
+
+// TestGetAndRemoveConcurrent verifies exactly one of many racing callers
+// takes the entry.
+func TestGetAndRemoveConcurrent(t *testing.T) {
+	cache, _ := NewLRU[string, int](1)
+	cache.Put("job", 42)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	taken := 0
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, ok := cache.GetAndRemove("job"); ok {
+				mu.Lock()
+				taken++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if taken != 1 {
+		t.Errorf("expected exactly one taker, got %d", taken)
+	}
+}
+
+// TestTryGet verifies TryGet looks up the key when the lock is free and
+// reports contention instead of blocking when it is held.
+func TestTryGet(t *testing.T) {
+	cache, _ := NewLRU[string, int](2)
+	cache.Put("a", 1)
+
+	if v, ok, acquired := cache.TryGet("a"); !acquired || !ok || v != 1 {
+		t.Errorf("expected 1, true, true, got %d, %v, %v", v, ok, acquired)
+	}
+	if _, ok, acquired := cache.TryGet("b"); !acquired || ok {
+		t.Errorf("expected miss with lock acquired, got %v, %v", ok, acquired)
+	}
+
+	cache.mu.RLock()
+	done := make(chan bool)
+	go func() {
+		_, _, acquired := cache.TryGet("a")
+		done <- acquired
+	}()
+	if acquired := <-done; acquired {
+		t.Error("expected TryGet to report contention")
+	}
+	cache.mu.RUnlock()
+	if s := cache.Stats(); s.Hits != 1 || s.Misses != 1 {
+		t.Errorf("expected skipped lookup not to be counted, got %+v", s)
+	}
+}
+
+// TestGrowShrink verifies relative capacity changes, eviction on shrink and
+// the lower bound.
+func TestGrowShrink(t *testing.T) {
+	cache, _ := NewLRU[int, string](2)
+	cache.Put(1, "one")
+	cache.Put(2, "two")
+
+	if err := cache.Grow(2); err != nil {
+		t.Fatal(err)
+	}
+	cache.Put(3, "three")
+	cache.Put(4, "four")
+	if cache.Cap() != 4 || cache.Len() != 4 {
+		t.Errorf("expected cap 4 len 4, got cap %d len %d", cache.Cap(), cache.Len())
+	}
+
+	if err := cache.Shrink(3); err != nil {
+		t.Fatal(err)
+	}
+	if cache.Cap() != 1 || cache.Len() != 1 || !cache.Contains(4) {
+		t.Errorf("expected only key 4 left, got %v", cache.Keys())
+	}
+
+	if err := cache.Shrink(1); err == nil {
+		t.Error("expected error shrinking to 0")
+	}
+	if err := cache.Grow(-5); err == nil {
+		t.Error("expected error growing below 1")
+	}
+	if cache.Cap() != 1 {
+		t.Errorf("expected failed resizes to keep cap 1, got %d", cache.Cap())
+	}
+}
+
+// TestValues verifies Values matches the order of Keys.
+func TestValues(t *testing.T) {
+	cache, _ := NewLRU[string, int](3)
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+	cache.Get("a")
+
+	keys := cache.Keys()
+	values := cache.Values()
+	if len(values) != len(keys) {
+		t.Fatalf("expected %d values, got %v", len(keys), values)
+	}
+	for i, k := range keys {
+		if v, _ := cache.Peek(k); values[i] != v {
+			t.Errorf("expected value %d for key %s at %d, got %d", v, k, i, values[i])
+		}
+	}
+	if values[0] != 2 || values[2] != 1 {
+		t.Errorf("expected [2 3 1], got %v", values)
+	}
+}
+
+// TestDemote verifies a demoted entry is evicted first.
+func TestDemote(t *testing.T) {
+	cache, _ := NewLRU[int, string](3)
+	cache.Put(1, "one")
+	cache.Put(2, "two")
+	cache.Put(3, "three")
+	cache.Get(3)
+
+	if !cache.Demote(3) {
+		t.Fatal("expected Demote to find key 3")
+	}
+	if cache.Demote(9) {
+		t.Error("expected Demote to fail for a missing key")
+	}
+	if k, _, _ := cache.PeekOldest(); k != 3 {
+		t.Errorf("expected 3 to be oldest, got %d", k)
+	}
+
+	cache.Put(4, "four")
+	if cache.Contains(3) || !cache.Contains(1) || !cache.Contains(2) {
+		t.Errorf("expected only 3 to be evicted, got %v", cache.Keys())
+	}
+}
+
+// TestGetWithDefault verifies hits return the stored value and promote it,
+// and misses return the default.
+func TestGetWithDefault(t *testing.T) {
+	cache, _ := NewLRU[string, int](2)
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+
+	if v := cache.GetWithDefault("a", -1); v != 1 {
+		t.Errorf("expected 1, got %d", v)
+	}
+	if v := cache.GetWithDefault("z", -1); v != -1 {
+		t.Errorf("expected default -1, got %d", v)
+	}
+
+	cache.Put("c", 3)
+	if !cache.Contains("a") || cache.Contains("b") {
+		t.Errorf("expected hit to promote a, got %v", cache.Keys())
+	}
+}
@@ -0,0 +1,57 @@
+// Package lruprom exports cache statistics as Prometheus metrics.
+package lruprom
+
+import (
+	lru "github.com/Prajwal306/go-lru-cache"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Source is the part of a cache the collector reads. *lru.LRU satisfies it.
+type Source interface {
+	Stats() lru.Stats
+	Cap() int
+}
+
+// Collector implements prometheus.Collector for a cache. It reads the
+// cache's existing counters on each scrape, so it adds no work to Get or Put.
+type Collector struct {
+	src       Source
+	hits      *prometheus.Desc
+	misses    *prometheus.Desc
+	evictions *prometheus.Desc
+	length    *prometheus.Desc
+	capacity  *prometheus.Desc
+}
+
+// NewCollector creates a collector for src. The name is attached to every
+// metric as the "cache" label so several caches can share a registry.
+func NewCollector(name string, src Source) *Collector {
+	labels := prometheus.Labels{"cache": name}
+	return &Collector{
+		src:       src,
+		hits:      prometheus.NewDesc("lru_cache_hits_total", "Number of Get calls that found a live entry.", nil, labels),
+		misses:    prometheus.NewDesc("lru_cache_misses_total", "Number of Get calls that found nothing.", nil, labels),
+		evictions: prometheus.NewDesc("lru_cache_evictions_total", "Number of entries evicted to stay within capacity.", nil, labels),
+		length:    prometheus.NewDesc("lru_cache_length", "Current number of entries.", nil, labels),
+		capacity:  prometheus.NewDesc("lru_cache_capacity", "Configured maximum number of entries.", nil, labels),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.hits
+	ch <- c.misses
+	ch <- c.evictions
+	ch <- c.length
+	ch <- c.capacity
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	s := c.src.Stats()
+	ch <- prometheus.MustNewConstMetric(c.hits, prometheus.CounterValue, float64(s.Hits))
+	ch <- prometheus.MustNewConstMetric(c.misses, prometheus.CounterValue, float64(s.Misses))
+	ch <- prometheus.MustNewConstMetric(c.evictions, prometheus.CounterValue, float64(s.Evictions))
+	ch <- prometheus.MustNewConstMetric(c.length, prometheus.GaugeValue, float64(s.Len))
+	ch <- prometheus.MustNewConstMetric(c.capacity, prometheus.GaugeValue, float64(c.src.Cap()))
+}
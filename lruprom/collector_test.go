@@ -0,0 +1,46 @@
+package lruprom
+
+import (
+	"strings"
+	"testing"
+
+	lru "github.com/Prajwal306/go-lru-cache"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestCollector verifies the gathered metric families and values.
+func TestCollector(t *testing.T) {
+	cache, _ := lru.NewLRU[int, string](2)
+	cache.Put(1, "one")
+	cache.Put(2, "two")
+	cache.Get(1)
+	cache.Get(3)
+	cache.Put(3, "three")
+
+	reg := prometheus.NewPedanticRegistry()
+	if err := reg.Register(NewCollector("test", cache)); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `
+# HELP lru_cache_capacity Configured maximum number of entries.
+# TYPE lru_cache_capacity gauge
+lru_cache_capacity{cache="test"} 2
+# HELP lru_cache_evictions_total Number of entries evicted to stay within capacity.
+# TYPE lru_cache_evictions_total counter
+lru_cache_evictions_total{cache="test"} 1
+# HELP lru_cache_hits_total Number of Get calls that found a live entry.
+# TYPE lru_cache_hits_total counter
+lru_cache_hits_total{cache="test"} 1
+# HELP lru_cache_length Current number of entries.
+# TYPE lru_cache_length gauge
+lru_cache_length{cache="test"} 2
+# HELP lru_cache_misses_total Number of Get calls that found nothing.
+# TYPE lru_cache_misses_total counter
+lru_cache_misses_total{cache="test"} 1
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(want)); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,58 @@
+package lru
+
+import "time"
+
+// State is the result of a lookup with GetState.
+type State int
+
+const (
+	// Miss means the key is not cached.
+	Miss State = iota
+	// Hit means the key is cached with a value.
+	Hit
+	// NegativeHit means the key is cached as known to be absent, by
+	// PutNegative.
+	NegativeHit
+)
+
+// String returns the name of the state.
+func (s State) String() string {
+	switch s {
+	case Hit:
+		return "Hit"
+	case NegativeHit:
+		return "NegativeHit"
+	default:
+		return "Miss"
+	}
+}
+
+// PutNegative caches the fact that key has no value, for example because a
+// backend reported it as not found, so lookups can skip the backend until
+// ttl passes. A ttl <= 0 means the entry never expires. The entry takes a
+// slot like any other and is replaced by a later Put. Get, Peek and
+// Contains report the key as absent; use GetState to tell a negative entry
+// from a miss. The insert and update callbacks are not called and no event
+// is published for a negative entry.
+func (c *LRU[K, V]) PutNegative(key K, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var zero V
+	c.store(key, zero, ttl, 0, true)
+}
+
+// GetState retrieves the value for the given key like Get, and reports
+// whether it was a Hit, a NegativeHit for a key stored with PutNegative, or
+// a Miss. A negative entry is promoted like a hit but counted as a miss in
+// Stats.
+func (c *LRU[K, V]) GetState(key K) (V, State) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.getState(key)
+}
+
+// live reports whether kv holds a value that has not expired.
+// Caller must hold the lock.
+func (c *LRU[K, V]) live(kv *entry[K, V]) bool {
+	return !kv.negative && !c.expired(kv)
+}
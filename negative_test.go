@@ -0,0 +1,205 @@
+package lru
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// TestPutNegative verifies a negative entry suppresses loader calls until
+// its TTL passes.
+func TestPutNegative(t *testing.T) {
+	clock := newFakeClock()
+	cache, _ := NewLRUWithOptions(WithCapacity[string, int](4), WithClock[string, int](clock))
+	calls := 0
+	lookup := func(key string) (int, bool) {
+		switch val, state := cache.GetState(key); state {
+		case Hit:
+			return val, true
+		case NegativeHit:
+			return 0, false
+		}
+		calls++ // the backend never has the key
+		cache.PutNegative(key, time.Minute)
+		return 0, false
+	}
+
+	for i := 0; i < 5; i++ {
+		if _, ok := lookup("gone"); ok {
+			t.Fatal("expected key to be absent")
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 backend call while negative entry is cached, got %d", calls)
+	}
+
+	clock.Advance(2 * time.Minute)
+	lookup("gone")
+	if calls != 2 {
+		t.Errorf("expected backend call after TTL, got %d calls", calls)
+	}
+}
+
+// TestNegativeEntryAbsent verifies Get, Peek and Contains report a negative
+// entry as absent and a Put replaces it.
+func TestNegativeEntryAbsent(t *testing.T) {
+	cache, _ := NewLRU[string, int](2)
+	cache.PutNegative("a", 0)
+
+	if _, ok := cache.Get("a"); ok {
+		t.Error("expected Get to report a negative entry as absent")
+	}
+	if _, ok := cache.Peek("a"); ok {
+		t.Error("expected Peek to report a negative entry as absent")
+	}
+	if cache.Contains("a") {
+		t.Error("expected Contains to report a negative entry as absent")
+	}
+	if _, state := cache.GetState("b"); state != Miss {
+		t.Errorf("expected Miss, got %v", state)
+	}
+
+	if stored := cache.PutIfAbsent("a", 1); !stored {
+		t.Error("expected PutIfAbsent to replace a negative entry")
+	}
+	if v, state := cache.GetState("a"); state != Hit || v != 1 {
+		t.Errorf("expected Hit with 1, got %v with %d", state, v)
+	}
+}
+
+// TestNegativeEntrySaveLoad verifies a negative entry is not saved, so it
+// never comes back from Load as a real zero value.
+func TestNegativeEntrySaveLoad(t *testing.T) {
+	cache, _ := NewLRU[string, int](4)
+	cache.Put("a", 1)
+	cache.PutNegative("gone", time.Minute)
+
+	var buf bytes.Buffer
+	if err := cache.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	restored, _ := NewLRU[string, int](4)
+	if err := restored.Load(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if _, state := restored.GetState("gone"); state != Miss {
+		t.Errorf("expected Miss after Load, got %v", state)
+	}
+	if v, ok := restored.Get("a"); !ok || v != 1 {
+		t.Errorf("expected a=1 after Load, got %v %v", v, ok)
+	}
+
+	data, err := json.Marshal(cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `[{"key":"a","value":1}]` {
+		t.Errorf("expected only a in JSON, got %s", data)
+	}
+}
+
+// TestNegativeEntryListings verifies negative entries are left out of
+// listings and bulk updates.
+func TestNegativeEntryListings(t *testing.T) {
+	cache, _ := NewLRU[string, int](4)
+	cache.PutNegative("gone", 0)
+	cache.Put("a", 1)
+	cache.PutNegative("missing", 0)
+
+	if keys := cache.Keys(); len(keys) != 1 || keys[0] != "a" {
+		t.Errorf("expected Keys [a], got %v", keys)
+	}
+	if values := cache.Values(); len(values) != 1 || values[0] != 1 {
+		t.Errorf("expected Values [1], got %v", values)
+	}
+	if snap := cache.Snapshot(); len(snap) != 1 || snap[0].Key != "a" {
+		t.Errorf("expected Snapshot of a, got %v", snap)
+	}
+	ranged := 0
+	cache.Range(func(string, int) bool { ranged++; return true })
+	if ranged != 1 {
+		t.Errorf("expected Range to visit 1 entry, got %d", ranged)
+	}
+	if k, _, _ := cache.PeekOldest(); k != "a" {
+		t.Errorf("expected PeekOldest a, got %q", k)
+	}
+	if k, _, _ := cache.PeekNewest(); k != "a" {
+		t.Errorf("expected PeekNewest a, got %q", k)
+	}
+	if n := cache.LiveLen(); n != 1 {
+		t.Errorf("expected LiveLen 1, got %d", n)
+	}
+	if _, ok := cache.LastAccess("gone"); ok {
+		t.Error("expected no last access for a negative entry")
+	}
+
+	cache.UpdateAll(func(key string, v int) int { return v + 10 })
+	if _, state := cache.GetState("gone"); state != NegativeHit {
+		t.Errorf("expected gone to stay negative, got %v", state)
+	}
+	if v, _ := cache.Get("a"); v != 11 {
+		t.Errorf("expected a=11, got %v", v)
+	}
+	cache.Filter(func(string, int) bool { return false })
+	if _, state := cache.GetState("missing"); state != NegativeHit {
+		t.Errorf("expected Filter to keep negative entries, got %v", state)
+	}
+	if n := InvalidatePrefix(cache, "miss"); n != 1 || cache.Len() != 1 {
+		t.Errorf("expected InvalidatePrefix to remove the negative entry, got %d and %v", n, cache.Len())
+	}
+}
+
+// TestNegativeEntryNotUpdated verifies UpdateValue treats a negative entry
+// as absent instead of giving it a value GetState would not report.
+func TestNegativeEntryNotUpdated(t *testing.T) {
+	cache, _ := NewLRU[string, int](2)
+	cache.PutNegative("gone", 0)
+	if cache.UpdateValue("gone", 5) {
+		t.Error("expected UpdateValue to fail for a negative entry")
+	}
+	if _, state := cache.GetState("gone"); state != NegativeHit {
+		t.Errorf("expected NegativeHit, got %v", state)
+	}
+}
+
+// TestNegativeEntryNotifications verifies storing a negative entry calls
+// neither the insert nor the update callback and publishes no event.
+func TestNegativeEntryNotifications(t *testing.T) {
+	cache, _ := NewLRU[string, int](2)
+	calls := 0
+	cache.SetInsertCallback(func(string, int) { calls++ })
+	cache.SetUpdateCallback(func(string, int) { calls++ })
+	events, unsubscribe := cache.Subscribe(4)
+	defer unsubscribe()
+
+	cache.PutNegative("x", 0)
+	cache.PutNegative("x", 0)
+	if calls != 0 {
+		t.Errorf("expected no insert or update callbacks, got %d", calls)
+	}
+	if got := drain(events); len(got) != 0 {
+		t.Errorf("expected no events, got %v", got)
+	}
+}
+
+// TestNegativeEntryRemoveOldest verifies RemoveOldest skips negative
+// entries like PeekOldest.
+func TestNegativeEntryRemoveOldest(t *testing.T) {
+	cache, _ := NewLRU[string, int](3)
+	cache.PutNegative("neg", 0)
+	cache.Put("a", 1)
+
+	if k, _, _ := cache.PeekOldest(); k != "a" {
+		t.Errorf("expected PeekOldest a, got %q", k)
+	}
+	if k, _, ok := cache.RemoveOldest(); !ok || k != "a" {
+		t.Errorf("expected RemoveOldest a, got %q %v", k, ok)
+	}
+	if _, _, ok := cache.RemoveOldest(); ok {
+		t.Error("expected RemoveOldest to find no live entry")
+	}
+	if _, state := cache.GetState("neg"); state != NegativeHit {
+		t.Errorf("expected the negative entry to stay, got %v", state)
+	}
+}
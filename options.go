@@ -0,0 +1,254 @@
+package lru
+
+import (
+	"container/list"
+	"errors"
+	"time"
+)
+
+// Option configures a cache created by NewLRUWithOptions.
+type Option[K comparable, V any] func(*LRU[K, V])
+
+// NewLRUWithOptions creates a new LRU cache configured by opts.
+// WithCapacity or WithNoCapacityLimit is required. Returns an error if the
+// capacity is <= 0 or the max cost is negative. WithNoCapacityLimit is
+// allowed only together with a max cost or a default TTL.
+func NewLRUWithOptions[K comparable, V any](opts ...Option[K, V]) (*LRU[K, V], error) {
+	c := &LRU[K, V]{
+		list: list.New(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.policy == nil {
+		c.policy = LRUPolicy{}
+	}
+	if c.clock == nil {
+		c.clock = realClock{}
+	}
+	if c.cap == NoCapacityLimit {
+		if c.maxCost <= 0 && c.ttl <= 0 {
+			return nil, errors.New("unlimited capacity requires a max cost or default TTL")
+		}
+	} else if c.cap <= 0 {
+		return nil, ErrInvalidCapacity
+	}
+	if c.resizer != nil && (c.cap == NoCapacityLimit || c.resizer.cfg.MaxCapacity < c.cap) {
+		return nil, errors.New("auto-resize max capacity must be at least the capacity")
+	}
+	if c.maxCost < 0 {
+		return nil, errors.New("max cost must not be negative")
+	}
+	if c.refresher != nil && (c.refreshAt <= 0 || c.refreshAt >= 1) {
+		return nil, errors.New("refresh threshold must be between 0 and 1")
+	}
+	c.idx = make(map[K]*list.Element, c.sizeHint())
+	return c, nil
+}
+
+// NoCapacityLimit is the capacity reported by Cap for a cache created with
+// WithNoCapacityLimit.
+const NoCapacityLimit = -1
+
+// WithCapacity sets the maximum number of items in the cache, which must be
+// greater than 0.
+func WithCapacity[K comparable, V any](capacity int) Option[K, V] {
+	return func(c *LRU[K, V]) {
+		// Store negative capacities as 0 so they are rejected like any
+		// other capacity <= 0 instead of being taken for NoCapacityLimit.
+		c.cap = max(capacity, 0)
+	}
+}
+
+// WithNoCapacityLimit creates a cache with no limit on the number of items,
+// bounded only by its max cost or default TTL, one of which must be set.
+func WithNoCapacityLimit[K comparable, V any]() Option[K, V] {
+	return func(c *LRU[K, V]) {
+		c.cap = NoCapacityLimit
+	}
+}
+
+// WithInitialSize preallocates room for n entries in the cache's index,
+// avoiding rehashing while it fills. By default the index is sized to the
+// capacity, or starts small with WithNoCapacityLimit. The index
+// is sized the same way again after Clear.
+func WithInitialSize[K comparable, V any](n int) Option[K, V] {
+	return func(c *LRU[K, V]) {
+		c.initSize = n
+	}
+}
+
+// WithDefaultTTL sets the TTL applied to entries stored with Put.
+// A ttl <= 0 means entries never expire.
+func WithDefaultTTL[K comparable, V any](ttl time.Duration) Option[K, V] {
+	return func(c *LRU[K, V]) {
+		c.ttl = ttl
+	}
+}
+
+// WithEvictionCallback sets the callback to be called when an item is evicted.
+func WithEvictionCallback[K comparable, V any](fn func(key K, value V)) Option[K, V] {
+	return func(c *LRU[K, V]) {
+		c.onEvict = fn
+	}
+}
+
+// WithAsyncEvictionCallback makes the eviction callback run on a background
+// goroutine instead of while the cache's lock is held, so a slow callback
+// does not stall other operations. Callbacks still run one at a time in
+// eviction order. Call FlushEvictions to wait for queued callbacks, for
+// example before shutdown. It also applies to expirations reported through
+// the eviction callback.
+func WithAsyncEvictionCallback[K comparable, V any]() Option[K, V] {
+	return func(c *LRU[K, V]) {
+		c.evictQ = newEvictQueue[K, V]()
+	}
+}
+
+// WithBeforeEvict sets fn to be called before an entry is evicted to keep
+// the cache within its capacity or cost budget, for example to write it
+// back to a backing store. If fn returns an error the entry is kept and
+// eviction stops, so the cache stays over its limits until a later write
+// retries the eviction, calling fn again for the same entry. Explicit
+// removals, such as Remove, Purge and RemoveOldest, do not call fn. fn is
+// called with the write lock held, so it must not call back into the cache.
+func WithBeforeEvict[K comparable, V any](fn func(key K, value V) error) Option[K, V] {
+	return func(c *LRU[K, V]) {
+		c.beforeEvict = fn
+	}
+}
+
+// WithExpirationCallback sets the callback to be called when an item expires.
+func WithExpirationCallback[K comparable, V any](fn func(key K, value V)) Option[K, V] {
+	return func(c *LRU[K, V]) {
+		c.onExpire = fn
+	}
+}
+
+// WithMaxCost bounds the total cost of the cache, as measured by sizer.
+// A maxCost of zero means the cost is unbounded. See NewLRUWithMaxCost.
+func WithMaxCost[K comparable, V any](maxCost int64, sizer func(key K, value V) int64) Option[K, V] {
+	return func(c *LRU[K, V]) {
+		c.maxCost = maxCost
+		c.sizer = sizer
+	}
+}
+
+// WithRefreshAhead makes Get reload an entry in the background once it has
+// lived past threshold, a fraction of its TTL between 0 and 1. The current
+// value is returned immediately and replaced when loader succeeds. At most
+// one refresh per key runs at a time, and a failed refresh keeps the current
+// value until it expires. Entries without a TTL are never refreshed.
+func WithRefreshAhead[K comparable, V any](threshold float64, loader func(K) (V, error)) Option[K, V] {
+	return func(c *LRU[K, V]) {
+		c.refreshAt = threshold
+		c.refresher = loader
+	}
+}
+
+// WithClock sets the clock used for expiry. The default, or a nil clock,
+// uses time.Now.
+func WithClock[K comparable, V any](clock Clock) Option[K, V] {
+	return func(c *LRU[K, V]) {
+		c.clock = clock
+	}
+}
+
+// WithValueIndex makes the cache keep a reverse index from values to the
+// keys holding them, for KeysForValue. If V is an interface type, the
+// values stored in it must be comparable, or storing them panics.
+func WithValueIndex[K comparable, V comparable]() Option[K, V] {
+	return func(c *LRU[K, V]) {
+		c.values = make(map[any]map[K]struct{})
+	}
+}
+
+// WithExpireBeforeEvict makes the cache remove all expired entries before
+// evicting a live one to stay within its capacity or cost budget, so space
+// held by stale entries is reclaimed first. This is an alternative to
+// StartJanitor that only does work when the cache is full, but each such
+// sweep takes time proportional to the number of entries.
+func WithExpireBeforeEvict[K comparable, V any]() Option[K, V] {
+	return func(c *LRU[K, V]) {
+		c.expireFirst = true
+	}
+}
+
+// WithPeekRefreshesTTL makes Peek restart the TTL of the entry it reads,
+// as Touch does, while still leaving its recency unchanged. This keeps
+// entries such as sessions alive while they are being read without
+// reordering them. Peek then takes the write lock. It is off by default.
+func WithPeekRefreshesTTL[K comparable, V any](enabled bool) Option[K, V] {
+	return func(c *LRU[K, V]) {
+		c.peekTTL = enabled
+	}
+}
+
+// WithCopyOnRead makes lookups of a single key, such as Get, Peek,
+// GetMany and GetOrPut, return copy(value) instead of the stored value, so
+// callers cannot modify cached data through a returned pointer, slice or
+// map. copy should return a deep copy. Bulk accessors such as Snapshot,
+// Values and Range still return the stored values. copy is called with the
+// cache lock held, so it must not call back into the cache.
+func WithCopyOnRead[K comparable, V any](copy func(V) V) Option[K, V] {
+	return func(c *LRU[K, V]) {
+		c.copier = copy
+	}
+}
+
+// WithEvictionBatch lets the cache hold up to n items beyond its capacity
+// before evicting, then evict n at once to get back to capacity, so the
+// eviction path runs once every n inserts instead of on every insert into
+// a full cache. Len therefore ranges up to Cap()+n-1 between batches. The
+// cost budget is still enforced on every write, and Resize, Grow, Shrink
+// and Unpin evict down to the capacity at once. A value <= 0 evicts one
+// item per insert, the default.
+func WithEvictionBatch[K comparable, V any](n int) Option[K, V] {
+	return func(c *LRU[K, V]) {
+		c.evictBatch = n
+	}
+}
+
+// WithReadPromotion sets whether reads such as Get and GetOrPut update an
+// entry's recency, or its frequency under LFUPolicy. It defaults to true.
+// When false, entries are evicted in the order they were written, as if
+// every Get were a Peek, but Get still counts hits and misses and records
+// the access time.
+func WithReadPromotion[K comparable, V any](enabled bool) Option[K, V] {
+	return func(c *LRU[K, V]) {
+		c.noPromote = !enabled
+	}
+}
+
+// WithPolicy sets the eviction policy. The default, or a nil p, is LRUPolicy.
+func WithPolicy[K comparable, V any](p Policy) Option[K, V] {
+	return func(c *LRU[K, V]) {
+		c.policy = p
+	}
+}
+
+// WithLFU makes the cache evict the least frequently used entry instead of
+// the least recently used one. It is shorthand for WithPolicy(NewLFUPolicy()).
+func WithLFU[K comparable, V any]() Option[K, V] {
+	return WithPolicy[K, V](NewLFUPolicy())
+}
+
+// WithFIFO makes the cache evict entries in insertion order. It is
+// shorthand for WithPolicy(FIFOPolicy{}).
+func WithFIFO[K comparable, V any]() Option[K, V] {
+	return WithPolicy[K, V](FIFOPolicy{})
+}
+
+// WithOverflowHandler sets fn to decide what happens when storing a new key
+// would exceed the cache's capacity or cost budget. fn is given the new key
+// and value and the key the eviction policy would evict next. It returns the
+// key to evict, which need not be victim, or false to reject the new entry,
+// leaving the cache as it is apart from entries already evicted for it.
+// If the returned key is not cached or is pinned, victim is evicted. fn is
+// called once per eviction with the write lock held, so it must not call
+// back into the cache. Without a handler, the policy's victims are evicted.
+func WithOverflowHandler[K comparable, V any](fn func(key K, value V, victim K) (evict K, ok bool)) Option[K, V] {
+	return func(c *LRU[K, V]) {
+		c.onOverflow = fn
+	}
+}
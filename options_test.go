@@ -0,0 +1,400 @@
+package lru
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestNewLRUWithOptions verifies each option takes effect.
+func TestNewLRUWithOptions(t *testing.T) {
+	clock := newFakeClock()
+	var evicted, expired []string
+	cache, err := NewLRUWithOptions(
+		WithCapacity[string, string](3),
+		WithDefaultTTL[string, string](time.Minute),
+		WithEvictionCallback(func(k, v string) {
+			evicted = append(evicted, k)
+		}),
+		WithExpirationCallback(func(k, v string) {
+			expired = append(expired, k)
+		}),
+		WithMaxCost(6, func(k, v string) int64 {
+			return int64(len(v))
+		}),
+		WithClock[string, string](clock),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cache.Cap() != 3 {
+		t.Errorf("expected cap 3, got %d", cache.Cap())
+	}
+
+	cache.Put("a", "aa")
+	cache.Put("b", "bb")
+	cache.Put("c", "cc")
+	cache.Put("d", "d") // capacity evicts a
+
+	cache.Put("e", "eeee") // cost evicts b and c
+	if len(evicted) != 3 || evicted[0] != "a" || evicted[1] != "b" || evicted[2] != "c" {
+		t.Errorf("expected evictions [a b c], got %v", evicted)
+	}
+
+	clock.Advance(time.Minute)
+	if _, ok := cache.Get("d"); ok {
+		t.Errorf("expected d to expire after the default TTL")
+	}
+	if len(expired) != 1 || expired[0] != "d" {
+		t.Errorf("expected expirations [d], got %v", expired)
+	}
+}
+
+// TestNewLRUWithOptionsInvalid ensures missing or invalid limits are rejected.
+func TestNewLRUWithOptionsInvalid(t *testing.T) {
+	if _, err := NewLRUWithOptions[int, int](); err == nil {
+		t.Errorf("expected error without a capacity")
+	}
+	if _, err := NewLRUWithOptions(WithCapacity[int, int](-1)); err == nil {
+		t.Errorf("expected error for negative capacity")
+	}
+	if _, err := NewLRUWithOptions(WithCapacity[int, int](1), WithMaxCost[int, int](-1, nil)); err == nil {
+		t.Errorf("expected error for negative max cost")
+	}
+}
+
+// TestNoCapacityLimit verifies an unlimited cache holds any number of items
+// until its cost budget forces eviction.
+func TestNoCapacityLimit(t *testing.T) {
+	cache, err := NewLRUWithOptions(
+		WithNoCapacityLimit[int, int](),
+		WithMaxCost(5000, func(k, v int) int64 { return 1 }),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cache.Cap() != NoCapacityLimit {
+		t.Errorf("expected Cap NoCapacityLimit, got %d", cache.Cap())
+	}
+
+	for i := 0; i < 5000; i++ {
+		cache.Put(i, i)
+	}
+	if cache.Len() != 5000 || cache.EvictionCount() != 0 {
+		t.Errorf("expected 5000 items and no evictions, got %d items and %d evictions", cache.Len(), cache.EvictionCount())
+	}
+
+	cache.Put(5000, 5000)
+	if cache.Len() != 5000 || cache.Contains(0) {
+		t.Errorf("expected the oldest item evicted by cost, got len %d", cache.Len())
+	}
+}
+
+// TestNoCapacityLimitWithTTL verifies an unlimited cache bounded only by TTL
+// expires its items.
+func TestNoCapacityLimitWithTTL(t *testing.T) {
+	clock := newFakeClock()
+	cache, err := NewLRUWithOptions(
+		WithNoCapacityLimit[int, string](),
+		WithDefaultTTL[int, string](time.Second),
+		WithClock[int, string](clock),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 1000; i++ {
+		cache.Put(i, "v")
+	}
+	clock.Advance(2 * time.Second)
+	if _, ok := cache.Get(0); ok {
+		t.Error("expected item to expire")
+	}
+}
+
+// TestNoCapacityLimitInvalid verifies an unlimited cache needs another bound.
+func TestNoCapacityLimitInvalid(t *testing.T) {
+	if _, err := NewLRUWithOptions(WithNoCapacityLimit[int, int]()); err == nil {
+		t.Error("expected error for unlimited cache without max cost or TTL")
+	}
+}
+
+// TestNegativeCapacityNotUnlimited verifies a capacity of -1 passed to
+// WithCapacity or a positional constructor is rejected rather than taken for
+// NoCapacityLimit.
+func TestNegativeCapacityNotUnlimited(t *testing.T) {
+	if _, err := NewLRUWithOptions(
+		WithCapacity[int, int](NoCapacityLimit),
+		WithDefaultTTL[int, int](time.Minute),
+	); !errors.Is(err, ErrInvalidCapacity) {
+		t.Errorf("expected ErrInvalidCapacity from WithCapacity, got %v", err)
+	}
+	if _, err := NewLRUWithTTL[int, int](-1, time.Minute); !errors.Is(err, ErrInvalidCapacity) {
+		t.Errorf("expected ErrInvalidCapacity from NewLRUWithTTL, got %v", err)
+	}
+	if _, err := NewLRUWithMaxCost[int, int](-1, 10, nil); !errors.Is(err, ErrInvalidCapacity) {
+		t.Errorf("expected ErrInvalidCapacity from NewLRUWithMaxCost, got %v", err)
+	}
+}
+
+// TestOverflowHandlerReject verifies a reject-on-full handler drops new
+// entries and keeps the existing ones.
+func TestOverflowHandlerReject(t *testing.T) {
+	cache, _ := NewLRUWithOptions(
+		WithCapacity[string, int](2),
+		WithOverflowHandler(func(key string, value int, victim string) (string, bool) {
+			return "", false
+		}),
+	)
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+	if err := cache.PutChecked("d", 4); !errors.Is(err, ErrCacheFull) {
+		t.Errorf("expected ErrCacheFull, got %v", err)
+	}
+	if cache.Add("e", 5) {
+		t.Error("expected rejected Add not to evict")
+	}
+	if !cache.Contains("a") || !cache.Contains("b") || cache.Len() != 2 {
+		t.Errorf("expected [a b] to survive, got %v", cache.Keys())
+	}
+
+	cache.Put("a", 10)
+	if v, _ := cache.Get("a"); v != 10 {
+		t.Errorf("expected overwrite to succeed when full, got %d", v)
+	}
+}
+
+// TestOverflowHandlerVictim verifies a handler can choose which entry to evict.
+func TestOverflowHandlerVictim(t *testing.T) {
+	var victims []string
+	cache, _ := NewLRUWithOptions(
+		WithCapacity[string, int](2),
+		WithOverflowHandler(func(key string, value int, victim string) (string, bool) {
+			victims = append(victims, victim)
+			return "b", true
+		}),
+	)
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+	if len(victims) != 1 || victims[0] != "a" {
+		t.Errorf("expected handler to be offered a, got %v", victims)
+	}
+	if !cache.Contains("a") || cache.Contains("b") || !cache.Contains("c") {
+		t.Errorf("expected b to be evicted, got %v", cache.Keys())
+	}
+	if cache.EvictionCount() != 1 {
+		t.Errorf("expected 1 eviction, got %d", cache.EvictionCount())
+	}
+
+	cache.Put("d", 4) // "b" is gone, so the policy's victim is evicted
+	if cache.Contains("a") || cache.Len() != 2 {
+		t.Errorf("expected a to be evicted, got %v", cache.Keys())
+	}
+}
+
+// TestWithInitialSize verifies a size hint does not change the capacity.
+func TestWithInitialSize(t *testing.T) {
+	cache, err := NewLRUWithOptions(WithCapacity[int, int](2), WithInitialSize[int, int](100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5; i++ {
+		cache.Put(i, i)
+	}
+	if cache.Len() != 2 || cache.Cap() != 2 {
+		t.Errorf("expected len 2 cap 2, got len %d cap %d", cache.Len(), cache.Cap())
+	}
+}
+
+// TestBeforeEvict verifies a failing hook keeps the entry over capacity and
+// a succeeding one lets it be evicted.
+func TestBeforeEvict(t *testing.T) {
+	flushOK := false
+	var flushed []int
+	cache, _ := NewLRUWithOptions(
+		WithCapacity[int, string](2),
+		WithBeforeEvict(func(k int, v string) error {
+			if !flushOK {
+				return errors.New("flush failed")
+			}
+			flushed = append(flushed, k)
+			return nil
+		}),
+	)
+	cache.Put(1, "one")
+	cache.Put(2, "two")
+	cache.Put(3, "three")
+	if cache.Len() != 3 || !cache.Contains(1) {
+		t.Errorf("expected failed flush to keep key 1 over capacity, got %v", cache.Keys())
+	}
+	if cache.EvictionCount() != 0 {
+		t.Errorf("expected no evictions, got %d", cache.EvictionCount())
+	}
+
+	flushOK = true
+	cache.Put(4, "four")
+	if cache.Len() != 2 || cache.Contains(1) || cache.Contains(2) {
+		t.Errorf("expected keys 1 and 2 evicted, got %v", cache.Keys())
+	}
+	if len(flushed) != 2 || flushed[0] != 1 || flushed[1] != 2 {
+		t.Errorf("expected flushes of [1 2], got %v", flushed)
+	}
+}
+
+// TestEvictionBatchResize verifies capacity changes are not deferred by
+// eviction batching.
+func TestEvictionBatchResize(t *testing.T) {
+	cache, _ := NewLRUWithOptions(WithCapacity[int, int](10), WithEvictionBatch[int, int](5))
+	for i := 0; i < 10; i++ {
+		cache.Put(i, i)
+	}
+	if err := cache.Resize(8); err != nil {
+		t.Fatal(err)
+	}
+	if cache.Len() != 8 {
+		t.Errorf("expected Resize to evict down to 8, got %d", cache.Len())
+	}
+	if err := cache.Shrink(2); err != nil {
+		t.Fatal(err)
+	}
+	if cache.Len() != 6 {
+		t.Errorf("expected Shrink to evict down to 6, got %d", cache.Len())
+	}
+
+	cache.Pin(9)
+	cache.Resize(1)
+	cache.Put(20, 20) // 9 is pinned, so the cache holds 9 and 20
+	cache.Unpin(9)
+	if cache.Len() != 1 {
+		t.Errorf("expected Unpin to evict down to 1, got %d", cache.Len())
+	}
+}
+
+// TestCopyOnRead verifies mutating a returned value leaves the cached value
+// unchanged.
+func TestCopyOnRead(t *testing.T) {
+	cache, _ := NewLRUWithOptions(
+		WithCapacity[string, []int](2),
+		WithCopyOnRead[string, []int](func(v []int) []int {
+			return append([]int(nil), v...)
+		}),
+	)
+	cache.Put("a", []int{1, 2, 3})
+
+	got, _ := cache.Get("a")
+	got[0] = 100
+	peeked, _ := cache.Peek("a")
+	peeked[1] = 200
+	if v, _ := cache.Get("a"); v[0] != 1 || v[1] != 2 {
+		t.Errorf("expected cached value [1 2 3], got %v", v)
+	}
+}
+
+// TestCopyOnReadStorePaths verifies values returned when GetOrPut,
+// GetOrLoad and Update store a value are copies too.
+func TestCopyOnReadStorePaths(t *testing.T) {
+	cache, _ := NewLRUWithOptions(
+		WithCapacity[string, []int](4),
+		WithCopyOnRead[string, []int](func(v []int) []int {
+			return append([]int(nil), v...)
+		}),
+	)
+
+	stored, _ := cache.GetOrPut("put", []int{1})
+	stored[0] = 100
+	loaded, _ := cache.GetOrLoad("load", func(string) ([]int, error) { return []int{1}, nil })
+	loaded[0] = 100
+	updated := cache.Update("update", func([]int, bool) []int { return []int{1} })
+	updated[0] = 100
+
+	for _, key := range []string{"put", "load", "update"} {
+		if v, _ := cache.Get(key); v[0] != 1 {
+			t.Errorf("%s: expected cached value [1], got %v", key, v)
+		}
+	}
+}
+
+// TestCopyOnReadDefault verifies values are shared without the option.
+func TestCopyOnReadDefault(t *testing.T) {
+	cache, _ := NewLRU[string, []int](2)
+	cache.Put("a", []int{1, 2, 3})
+	got, _ := cache.Get("a")
+	got[0] = 100
+	if v, _ := cache.Get("a"); v[0] != 100 {
+		t.Errorf("expected shared value, got %v", v)
+	}
+}
+
+// TestEvictionBatch verifies Len stays within [cap, cap+n) once full and
+// entries are evicted n at a time in LRU order.
+func TestEvictionBatch(t *testing.T) {
+	var evicted []int
+	cache, _ := NewLRUWithOptions(
+		WithCapacity[int, int](10),
+		WithEvictionBatch[int, int](3),
+		WithEvictionCallback(func(k, v int) { evicted = append(evicted, k) }),
+	)
+	for i := 0; i < 10; i++ {
+		cache.Put(i, i)
+	}
+	for i := 10; i < 40; i++ {
+		cache.Put(i, i)
+		if n := cache.Len(); n < 10 || n >= 13 {
+			t.Fatalf("expected len in [10, 13), got %d", n)
+		}
+	}
+
+	cache.Put(40, 40)
+	cache.Put(41, 41)
+	if cache.Len() != 12 {
+		t.Errorf("expected len 12 before the next batch, got %d", cache.Len())
+	}
+	before := len(evicted)
+	cache.Put(42, 42)
+	if cache.Len() != 10 || len(evicted)-before != 3 {
+		t.Errorf("expected a batch of 3 evictions down to 10, got len %d and %d evictions", cache.Len(), len(evicted)-before)
+	}
+	for i, k := range evicted {
+		if k != i {
+			t.Errorf("expected evictions in LRU order, got %v", evicted)
+			break
+		}
+	}
+}
+
+// TestReadPromotionDisabled verifies Gets do not change the eviction order
+// but still count hits.
+func TestReadPromotionDisabled(t *testing.T) {
+	cache, _ := NewLRUWithOptions(WithCapacity[int, string](2), WithReadPromotion[int, string](false))
+	cache.Put(1, "one")
+	cache.Put(2, "two")
+	cache.Get(1)
+	cache.Get(1)
+	cache.Put(3, "three")
+
+	if cache.Contains(1) || !cache.Contains(2) {
+		t.Errorf("expected 1 to be evicted despite Gets, got %v", cache.Keys())
+	}
+	if s := cache.Stats(); s.Hits != 2 {
+		t.Errorf("expected 2 hits, got %d", s.Hits)
+	}
+	cache.Put(2, "deux")
+	cache.Put(4, "four")
+	if !cache.Contains(2) || cache.Contains(3) {
+		t.Errorf("expected Put to still promote 2, got %v", cache.Keys())
+	}
+}
+
+// TestReadPromotionDefault verifies Gets promote by default.
+func TestReadPromotionDefault(t *testing.T) {
+	cache, _ := NewLRUWithOptions(WithCapacity[int, string](2), WithReadPromotion[int, string](true))
+	cache.Put(1, "one")
+	cache.Put(2, "two")
+	cache.Get(1)
+	cache.Put(3, "three")
+	if !cache.Contains(1) || cache.Contains(2) {
+		t.Errorf("expected 2 to be evicted, got %v", cache.Keys())
+	}
+}
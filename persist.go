@@ -0,0 +1,53 @@
+package lru
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Save writes the live entries to w using encoding/gob, one record at a time
+// from least to most recently used. Expiry times are not saved.
+//
+// If K or V is an interface type, the concrete types stored in it must be
+// registered with gob.Register before calling Save or Load.
+func (c *LRU[K, V]) Save(w io.Writer) error {
+	enc := gob.NewEncoder(w)
+	for _, rec := range c.Snapshot() {
+		if err := enc.Encode(rec); err != nil {
+			return fmt.Errorf("lru: save entry: %w", err)
+		}
+	}
+	return nil
+}
+
+// Load replaces the contents of the cache with entries written by Save,
+// restoring their recency order. If there are more entries than the
+// capacity, the oldest are evicted as they load. Loaded entries get the
+// default TTL. The cache is only modified if the whole stream decodes.
+func (c *LRU[K, V]) Load(r io.Reader) error {
+	dec := gob.NewDecoder(r)
+	var recs []Entry[K, V]
+	for {
+		var rec Entry[K, V]
+		err := dec.Decode(&rec)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("lru: load entry: %w", err)
+		}
+		recs = append(recs, rec)
+	}
+	c.restore(recs)
+	return nil
+}
+
+// restore clears the cache and inserts recs from least to most recently used.
+func (c *LRU[K, V]) restore(recs []Entry[K, V]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.clear()
+	c.warmUp(recs)
+}
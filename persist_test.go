@@ -0,0 +1,51 @@
+package lru
+
+import (
+	"bytes"
+	"testing"
+)
+
+type point struct {
+	X, Y int
+}
+
+// TestSaveLoad verifies data and ordering survive a gob round trip.
+func TestSaveLoad(t *testing.T) {
+	cache, _ := NewLRU[string, point](3)
+	cache.Put("a", point{1, 2})
+	cache.Put("b", point{3, 4})
+	cache.Put("c", point{5, 6})
+	cache.Get("a")
+
+	var buf bytes.Buffer
+	if err := cache.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	restored, _ := NewLRU[string, point](3)
+	restored.Put("stale", point{})
+	if err := restored.Load(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	keys := restored.Keys()
+	if len(keys) != 3 || keys[0] != "b" || keys[1] != "c" || keys[2] != "a" {
+		t.Errorf("expected order [b c a], got %v", keys)
+	}
+	if p, ok := restored.Peek("b"); !ok || p != (point{3, 4}) {
+		t.Errorf("expected {3 4}, got %v", p)
+	}
+}
+
+// TestLoadCorrupt verifies a bad stream leaves the cache untouched.
+func TestLoadCorrupt(t *testing.T) {
+	cache, _ := NewLRU[string, int](2)
+	cache.Put("a", 1)
+
+	if err := cache.Load(bytes.NewBufferString("not gob")); err == nil {
+		t.Errorf("expected error loading corrupt data")
+	}
+	if !cache.Contains("a") {
+		t.Errorf("expected cache to be unchanged after a failed load")
+	}
+}
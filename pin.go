@@ -0,0 +1,36 @@
+package lru
+
+// Pin protects the entry for key from eviction: when the cache is over its
+// capacity or cost budget, the next unpinned entry is evicted instead.
+// Pinned entries still expire and can still be removed by key, but
+// RemoveOldest skips them. Returns false if the key is not present.
+//
+// If every entry other than the one just stored is pinned, the cache grows
+// beyond its limits rather than evicting a pinned entry, and shrinks back
+// as entries are unpinned.
+func (c *LRU[K, V]) Pin(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.idx[key]
+	if !ok {
+		return false
+	}
+	kv := el.Value.(*entry[K, V])
+	if c.expired(kv) {
+		c.expire(el)
+		return false
+	}
+	kv.pinned = true
+	return true
+}
+
+// Unpin makes the entry for key evictable again, evicting entries if the
+// cache is over its limits. It is a no-op if the key is not present.
+func (c *LRU[K, V]) Unpin(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.idx[key]; ok {
+		el.Value.(*entry[K, V]).pinned = false
+		c.evictToLimits(nil)
+	}
+}
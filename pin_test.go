@@ -0,0 +1,73 @@
+package lru
+
+import "testing"
+
+// TestPin verifies pinned keys survive a flood of inserts and become
+// evictable again once unpinned.
+func TestPin(t *testing.T) {
+	cache, _ := NewLRU[int, int](3)
+	cache.Put(1, 1)
+	cache.Put(2, 2)
+	if !cache.Pin(1) {
+		t.Fatal("expected Pin to find key 1")
+	}
+	if cache.Pin(99) {
+		t.Error("expected Pin to fail for a missing key")
+	}
+
+	for i := 10; i < 100; i++ {
+		cache.Put(i, i)
+	}
+	if !cache.Contains(1) || cache.Len() != 3 {
+		t.Errorf("expected pinned key to survive at capacity, got %v", cache.Keys())
+	}
+
+	cache.Unpin(1)
+	cache.Put(100, 100)
+	cache.Put(101, 101)
+	if cache.Contains(1) {
+		t.Error("expected unpinned key to be evicted")
+	}
+}
+
+// TestPinAll verifies the cache grows past its capacity when every other
+// entry is pinned and shrinks back on Unpin.
+func TestPinAll(t *testing.T) {
+	cache, _ := NewLRU[int, int](2)
+	cache.Put(1, 1)
+	cache.Put(2, 2)
+	cache.Pin(1)
+	cache.Pin(2)
+
+	cache.Put(3, 3)
+	if cache.Len() != 3 {
+		t.Errorf("expected cache to grow to 3, got %d", cache.Len())
+	}
+	cache.Put(4, 4) // evicts the unpinned 3
+	if cache.Len() != 3 || cache.Contains(3) {
+		t.Errorf("expected 3 to be evicted, got %v", cache.Keys())
+	}
+
+	cache.Unpin(1)
+	if cache.Len() != 2 || cache.Contains(1) {
+		t.Errorf("expected Unpin to evict back to capacity, got %v", cache.Keys())
+	}
+}
+
+// TestPinRemoveOldest verifies RemoveOldest skips pinned entries.
+func TestPinRemoveOldest(t *testing.T) {
+	cache, _ := NewLRU[string, int](3)
+	cache.Put("pinned", 1)
+	cache.Put("a", 2)
+	cache.Pin("pinned")
+
+	if k, _, ok := cache.RemoveOldest(); !ok || k != "a" {
+		t.Errorf("expected RemoveOldest to skip the pinned entry, got %q %v", k, ok)
+	}
+	if _, _, ok := cache.RemoveOldest(); ok {
+		t.Error("expected RemoveOldest to find no unpinned entry")
+	}
+	if !cache.Contains("pinned") {
+		t.Error("expected the pinned entry to stay")
+	}
+}
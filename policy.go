@@ -0,0 +1,112 @@
+package lru
+
+import "container/list"
+
+// Policy decides the order in which a cache evicts its entries.
+//
+// The cache keeps its entries in a list, pushing new ones to the front. A
+// policy may reorder that list and keep its own state keyed by element. Use
+// ElementKey to get the key stored in an element. All methods are called
+// with the cache's write lock held, so they must not call back into the
+// cache. A policy value must not be shared between caches unless it is
+// stateless.
+type Policy interface {
+	// OnInsert is called after el has been pushed to the front of l.
+	OnInsert(l *list.List, el *list.Element)
+	// OnAccess is called when el is read by Get or overwritten by Put.
+	OnAccess(l *list.List, el *list.Element)
+	// OnRemove is called after el has been removed from the cache.
+	OnRemove(el *list.Element)
+	// Victim returns the eviction candidate following after, or the first
+	// candidate if after is nil. It returns nil when there are no more.
+	Victim(l *list.List, after *list.Element) *list.Element
+	// Reset is called after all entries have been cleared from l.
+	Reset()
+}
+
+// PolicyCloner is implemented by policies that can be copied by Clone.
+// Clone returns ErrPolicyNotCloneable if the cache's policy does not
+// implement it.
+type PolicyCloner interface {
+	// ClonePolicy returns a policy for a cloned cache with the same state.
+	// elems maps each element of the original cache to its copy; elements
+	// missing from it were left out of the clone and must be dropped.
+	// Stateless policies may return themselves.
+	ClonePolicy(elems map[*list.Element]*list.Element) Policy
+}
+
+// PolicyEvicter is implemented by policies that treat entries evicted to
+// make room differently from entries removed for any other reason, such as
+// Remove, expiry or an overwrite too large to fit. When the cache evicts an
+// entry, it calls OnEvict instead of OnRemove on such a policy.
+type PolicyEvicter interface {
+	// OnEvict is called after el has been evicted from the cache.
+	OnEvict(el *list.Element)
+}
+
+// ElementKey returns the key stored in a cache list element. It is meant
+// for Policy implementations.
+func ElementKey[K comparable, V any](el *list.Element) K {
+	return el.Value.(*entry[K, V]).key
+}
+
+// LRUPolicy evicts the least recently used entry. It is the default policy.
+type LRUPolicy struct{}
+
+// OnInsert implements Policy.
+func (LRUPolicy) OnInsert(l *list.List, el *list.Element) {}
+
+// OnAccess moves el to the front of l.
+func (LRUPolicy) OnAccess(l *list.List, el *list.Element) {
+	l.MoveToFront(el)
+}
+
+// OnRemove implements Policy.
+func (LRUPolicy) OnRemove(el *list.Element) {}
+
+// Victim walks l from the back.
+func (LRUPolicy) Victim(l *list.List, after *list.Element) *list.Element {
+	if after == nil {
+		return l.Back()
+	}
+	return after.Prev()
+}
+
+// Reset implements Policy.
+func (LRUPolicy) Reset() {}
+
+// ClonePolicy implements PolicyCloner. LRUPolicy is stateless, so it
+// returns itself.
+func (p LRUPolicy) ClonePolicy(elems map[*list.Element]*list.Element) Policy {
+	return p
+}
+
+// FIFOPolicy evicts entries in insertion order. Access does not move
+// entries, so it does not protect them from eviction.
+type FIFOPolicy struct{}
+
+// OnInsert implements Policy.
+func (FIFOPolicy) OnInsert(l *list.List, el *list.Element) {}
+
+// OnAccess implements Policy.
+func (FIFOPolicy) OnAccess(l *list.List, el *list.Element) {}
+
+// OnRemove implements Policy.
+func (FIFOPolicy) OnRemove(el *list.Element) {}
+
+// Victim walks l from the back.
+func (FIFOPolicy) Victim(l *list.List, after *list.Element) *list.Element {
+	if after == nil {
+		return l.Back()
+	}
+	return after.Prev()
+}
+
+// Reset implements Policy.
+func (FIFOPolicy) Reset() {}
+
+// ClonePolicy implements PolicyCloner. FIFOPolicy is stateless, so it
+// returns itself.
+func (p FIFOPolicy) ClonePolicy(elems map[*list.Element]*list.Element) Policy {
+	return p
+}
@@ -0,0 +1,74 @@
+package lru
+
+import (
+	"container/list"
+	"testing"
+)
+
+// evictKeyPolicy always evicts a chosen key first, then falls back to LRU.
+type evictKeyPolicy struct {
+	LRUPolicy
+	key      int
+	accessed int
+}
+
+func (p *evictKeyPolicy) OnAccess(l *list.List, el *list.Element) {
+	p.accessed++
+	p.LRUPolicy.OnAccess(l, el)
+}
+
+func (p *evictKeyPolicy) Victim(l *list.List, after *list.Element) *list.Element {
+	if after == nil {
+		for el := l.Back(); el != nil; el = el.Prev() {
+			if ElementKey[int, string](el) == p.key {
+				return el
+			}
+		}
+	} else if ElementKey[int, string](after) == p.key {
+		after = nil
+	}
+	el := p.LRUPolicy.Victim(l, after)
+	if el != nil && ElementKey[int, string](el) == p.key {
+		el = el.Prev()
+	}
+	return el
+}
+
+// TestCustomPolicy verifies the cache consults a custom policy.
+func TestCustomPolicy(t *testing.T) {
+	p := &evictKeyPolicy{key: 2}
+	cache, err := NewLRUWithOptions(WithCapacity[int, string](3), WithPolicy[int, string](p))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cache.Put(1, "one")
+	cache.Put(2, "two")
+	cache.Put(3, "three")
+	cache.Get(2)
+	cache.Put(4, "four") // evicts 2 although 1 is least recently used
+
+	if cache.Contains(2) {
+		t.Errorf("expected key 2 to be evicted by the custom policy")
+	}
+	if !cache.Contains(1) {
+		t.Errorf("expected key 1 to survive")
+	}
+	if p.accessed != 1 {
+		t.Errorf("expected one OnAccess call, got %d", p.accessed)
+	}
+
+	cache.Put(5, "five") // no key 2 left, falls back to LRU
+	if cache.Contains(1) {
+		t.Errorf("expected key 1 to be evicted by the LRU fallback")
+	}
+}
+
+// TestDefaultPolicy verifies NewLRU uses LRUPolicy.
+func TestDefaultPolicy(t *testing.T) {
+	cache, _ := NewLRU[int, string](1)
+
+	if _, ok := cache.policy.(LRUPolicy); !ok {
+		t.Errorf("expected LRUPolicy by default, got %T", cache.policy)
+	}
+}
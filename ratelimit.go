@@ -0,0 +1,48 @@
+package lru
+
+import (
+	"errors"
+	"time"
+)
+
+// RateLimiter allows up to a fixed number of events per key in each time
+// window. A key's window starts with its first event and its count is
+// dropped when the window ends. Counts are kept in an LRU cache, so when
+// more keys are active than its capacity the least recently seen keys are
+// forgotten and start a fresh window.
+type RateLimiter[K comparable] struct {
+	counts *LRU[K, int]
+	limit  int
+}
+
+// NewRateLimiter creates a rate limiter tracking up to capacity keys, each
+// allowed limit events per window. opts configure the underlying cache, for
+// example WithClock in tests. Returns an error if capacity, limit or window
+// is <= 0.
+func NewRateLimiter[K comparable](capacity, limit int, window time.Duration, opts ...Option[K, int]) (*RateLimiter[K], error) {
+	if capacity <= 0 {
+		return nil, ErrInvalidCapacity
+	}
+	if limit <= 0 {
+		return nil, errors.New("rate limit must be greater than 0")
+	}
+	if window <= 0 {
+		return nil, errors.New("rate limit window must be greater than 0")
+	}
+	opts = append([]Option[K, int]{
+		WithCapacity[K, int](capacity),
+		WithDefaultTTL[K, int](window),
+	}, opts...)
+	counts, err := NewLRUWithOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &RateLimiter[K]{counts: counts, limit: limit}, nil
+}
+
+// Allow records an event for key and reports whether it is within the
+// limit for the key's current window. Events that are not allowed still
+// count toward the window.
+func (r *RateLimiter[K]) Allow(key K) bool {
+	return Increment(r.counts, key, 1) <= r.limit
+}
@@ -0,0 +1,74 @@
+package lru
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestRateLimiter verifies each key is allowed limit events per window and
+// is allowed again once the window passes.
+func TestRateLimiter(t *testing.T) {
+	clock := newFakeClock()
+	limiter, err := NewRateLimiter(10, 3, time.Minute, WithClock[string, int](clock))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if !limiter.Allow("a") {
+			t.Fatalf("expected event %d to be allowed", i)
+		}
+	}
+	if limiter.Allow("a") {
+		t.Error("expected the fourth event to be blocked")
+	}
+	if !limiter.Allow("b") {
+		t.Error("expected another key to have its own limit")
+	}
+
+	clock.Advance(30 * time.Second)
+	if limiter.Allow("a") {
+		t.Error("expected the key to stay blocked within its window")
+	}
+	clock.Advance(30 * time.Second)
+	if !limiter.Allow("a") {
+		t.Error("expected the key to be allowed in a new window")
+	}
+}
+
+// TestRateLimiterForgetsIdleKeys verifies idle keys expire and that keys
+// beyond capacity are evicted.
+func TestRateLimiterForgetsIdleKeys(t *testing.T) {
+	clock := newFakeClock()
+	limiter, _ := NewRateLimiter(2, 1, time.Minute, WithClock[string, int](clock))
+	limiter.Allow("a")
+	limiter.Allow("b")
+	limiter.Allow("c") // evicts a
+	if limiter.counts.Contains("a") || limiter.counts.Len() != 2 {
+		t.Errorf("expected a to be evicted, got %v", limiter.counts.Keys())
+	}
+	if !limiter.Allow("a") {
+		t.Error("expected an evicted key to start a new window")
+	}
+
+	clock.Advance(time.Minute)
+	if n := limiter.counts.LiveLen(); n != 0 {
+		t.Errorf("expected idle keys to expire, got %d live", n)
+	}
+}
+
+// TestNewRateLimiterInvalid verifies invalid arguments are rejected.
+func TestNewRateLimiterInvalid(t *testing.T) {
+	if _, err := NewRateLimiter[string](0, 1, time.Minute); err == nil {
+		t.Error("expected an error for zero capacity")
+	}
+	if _, err := NewRateLimiter[string](NoCapacityLimit, 1, time.Minute); !errors.Is(err, ErrInvalidCapacity) {
+		t.Errorf("expected ErrInvalidCapacity for unlimited capacity, got %v", err)
+	}
+	if _, err := NewRateLimiter[string](1, 0, time.Minute); err == nil {
+		t.Error("expected an error for zero limit")
+	}
+	if _, err := NewRateLimiter[string](1, 1, 0); err == nil {
+		t.Error("expected an error for zero window")
+	}
+}
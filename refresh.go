@@ -0,0 +1,61 @@
+package lru
+
+import "time"
+
+// maybeRefresh starts a background refresh of kv if refresh-ahead is enabled
+// and kv is past its refresh threshold. Negative entries are never
+// refreshed. Caller must hold the write lock.
+func (c *LRU[K, V]) maybeRefresh(kv *entry[K, V]) {
+	if c.refresher == nil || kv.ttl == 0 || kv.negative {
+		return
+	}
+	refreshAt := kv.expires.Add(-kv.ttl + time.Duration(float64(kv.ttl)*c.refreshAt))
+	if c.now().Before(refreshAt) {
+		return
+	}
+	if _, ok := c.refreshing[kv.key]; ok {
+		return
+	}
+	if c.refreshing == nil {
+		c.refreshing = make(map[K]struct{})
+	}
+	c.refreshing[kv.key] = struct{}{}
+	go c.refresh(kv, kv.version)
+}
+
+// refresh reloads the key of kv and, if kv is still cached with the value it
+// had at the given version, replaces its value and restarts its TTL without
+// changing its recency. The loaded value is dropped if the key was written
+// or removed while it loaded, so it never overwrites a newer value.
+func (c *LRU[K, V]) refresh(kv *entry[K, V], version uint64) {
+	key := kv.key
+	val, err := c.refresher(key)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.refreshing, key)
+	if err != nil {
+		return
+	}
+	el, ok := c.idx[key]
+	if !ok || el.Value.(*entry[K, V]) != kv || kv.version != version || kv.negative {
+		return
+	}
+	cost := c.replacementCost(kv, val)
+	if c.tooLarge(cost) {
+		c.removeElement(el)
+		c.notifyRemove(key, kv.val, Replaced)
+		return
+	}
+	old := kv.val
+	c.setValue(kv, val)
+	kv.expires = c.deadline(kv.ttl)
+	c.cost += cost - kv.cost
+	kv.cost = cost
+	if c.onUpdate != nil {
+		c.onUpdate(key, val)
+	}
+	c.publish(EventUpdate, key, val)
+	c.notifyRemove(key, old, Replaced)
+	c.evictOverflow(el)
+}
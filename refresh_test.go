@@ -0,0 +1,171 @@
+package lru
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestRefreshAhead verifies a Get past the threshold serves the current value
+// and starts a single background refresh.
+func TestRefreshAhead(t *testing.T) {
+	clock := newFakeClock()
+	var calls atomic.Int32
+	release := make(chan struct{})
+	cache, err := NewLRUWithOptions(
+		WithCapacity[int, string](2),
+		WithDefaultTTL[int, string](10*time.Second),
+		WithClock[int, string](clock),
+		WithRefreshAhead(0.8, func(k int) (string, error) {
+			calls.Add(1)
+			<-release
+			return "new", nil
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cache.Put(1, "old")
+	clock.Advance(7 * time.Second)
+	cache.Get(1)
+	if calls.Load() != 0 {
+		t.Fatalf("expected no refresh before the threshold")
+	}
+
+	clock.Advance(time.Second)
+	for i := 0; i < 5; i++ {
+		if val, ok := cache.Get(1); !ok || val != "old" {
+			t.Errorf("expected old value while refreshing, got %v", val)
+		}
+	}
+
+	close(release)
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if val, _ := cache.Peek(1); val == "new" {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if val, _ := cache.Peek(1); val != "new" {
+		t.Errorf("expected refreshed value, got %v", val)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected exactly one refresh, got %d", calls.Load())
+	}
+
+	clock.Advance(5 * time.Second) // refresh restarted the TTL
+	if !cache.Contains(1) {
+		t.Errorf("expected refreshed entry to have a new deadline")
+	}
+}
+
+// TestRefreshAheadError verifies a failed refresh keeps the current value.
+func TestRefreshAheadError(t *testing.T) {
+	clock := newFakeClock()
+	done := make(chan int, 10)
+	cache, _ := NewLRUWithOptions(
+		WithCapacity[int, string](2),
+		WithClock[int, string](clock),
+		WithRefreshAhead(0.5, func(k int) (string, error) {
+			defer func() { done <- k }()
+			return "", errors.New("backend down")
+		}),
+	)
+
+	cache.PutWithTTL(1, "old", 10*time.Second)
+	cache.Put(2, "two") // no TTL, never refreshed
+	clock.Advance(6 * time.Second)
+	cache.Get(2)
+	cache.Get(1)
+	if k := <-done; k != 1 {
+		t.Errorf("expected refresh of key 1, got key %d", k)
+	}
+
+	if val, ok := cache.Get(1); !ok || val != "old" {
+		t.Errorf("expected old value after failed refresh, got %v", val)
+	}
+}
+
+// TestRefreshAheadDropsStaleResult verifies a refresh does not overwrite a
+// value written while it was loading.
+func TestRefreshAheadDropsStaleResult(t *testing.T) {
+	clock := newFakeClock()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	cache, _ := NewLRUWithOptions(
+		WithCapacity[string, string](2),
+		WithClock[string, string](clock),
+		WithRefreshAhead(0.5, func(k string) (string, error) {
+			close(started)
+			<-release
+			return "loaded", nil
+		}),
+	)
+
+	cache.PutWithTTL("k", "old", 10*time.Second)
+	clock.Advance(6 * time.Second)
+	cache.Get("k")
+	<-started
+	cache.PutWithTTL("k", "newer", 10*time.Second)
+	close(release)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		cache.mu.RLock()
+		n := len(cache.refreshing)
+		cache.mu.RUnlock()
+		if n == 0 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if val, _ := cache.Peek("k"); val != "newer" {
+		t.Errorf("expected newer value to be kept, got %v", val)
+	}
+}
+
+// TestRefreshAheadKeepsExplicitCost verifies a refresh keeps a cost given
+// to PutWithCost when there is no sizer.
+func TestRefreshAheadKeepsExplicitCost(t *testing.T) {
+	clock := newFakeClock()
+	done := make(chan struct{})
+	cache, _ := NewLRUWithOptions(
+		WithCapacity[int, string](2),
+		WithDefaultTTL[int, string](10*time.Second),
+		WithMaxCost[int, string](100, nil),
+		WithClock[int, string](clock),
+		WithRefreshAhead(0.5, func(k int) (string, error) {
+			defer close(done)
+			return "new", nil
+		}),
+	)
+
+	cache.PutWithCost(1, "old", 40)
+	clock.Advance(6 * time.Second)
+	cache.Get(1)
+	<-done
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if val, _ := cache.Peek(1); val == "new" {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if val, _ := cache.Peek(1); val != "new" || cache.Cost() != 40 {
+		t.Errorf("expected new with cost 40, got %v with cost %d", val, cache.Cost())
+	}
+}
+
+// TestRefreshAheadInvalidThreshold ensures thresholds outside (0, 1) are rejected.
+func TestRefreshAheadInvalidThreshold(t *testing.T) {
+	loader := func(k int) (int, error) { return k, nil }
+	for _, threshold := range []float64{0, 1, 1.5} {
+		if _, err := NewLRUWithOptions(WithCapacity[int, int](1), WithRefreshAhead(threshold, loader)); err == nil {
+			t.Errorf("expected error for threshold %v", threshold)
+		}
+	}
+}
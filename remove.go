@@ -0,0 +1,68 @@
+package lru
+
+// RemoveReason says why an entry left the cache, as reported to the
+// callback set with SetOnRemove.
+type RemoveReason int
+
+const (
+	// Evicted means the entry was evicted to make room, by a resize or by
+	// RemoveOldest.
+	Evicted RemoveReason = iota
+	// Expired means the entry's TTL passed.
+	Expired
+	// Replaced means the entry's value was overwritten. The callback
+	// receives the old value.
+	Replaced
+	// Manual means the entry was removed by a call such as Remove, Filter
+	// or Purge.
+	Manual
+)
+
+// String returns the name of the reason.
+func (r RemoveReason) String() string {
+	switch r {
+	case Evicted:
+		return "Evicted"
+	case Expired:
+		return "Expired"
+	case Replaced:
+		return "Replaced"
+	case Manual:
+		return "Manual"
+	default:
+		return "Unknown"
+	}
+}
+
+// SetOnRemove sets a callback to be called whenever a value leaves the
+// cache, with the reason it left. It is called in addition to the
+// eviction, expiration and update callbacks, synchronously with the write
+// lock held, so fn must not call back into the cache. Clear does not call
+// it. Pass nil to remove it.
+func (c *LRU[K, V]) SetOnRemove(fn func(key K, value V, reason RemoveReason)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onRemove = fn
+}
+
+// notifyRemove calls the remove callback, if set, and publishes the
+// matching event. Caller must hold the write lock.
+func (c *LRU[K, V]) notifyRemove(key K, value V, reason RemoveReason) {
+	if c.onRemove != nil {
+		c.onRemove(key, value, reason)
+	}
+	switch reason {
+	case Evicted:
+		c.publish(EventEvict, key, value)
+	case Expired:
+		c.publish(EventExpire, key, value)
+	case Manual:
+		c.publish(EventRemove, key, value)
+	case Replaced:
+		// A replaced value is published as an update with the new value,
+		// unless the new value was too large and the entry is gone.
+		if _, ok := c.idx[key]; !ok {
+			c.publish(EventRemove, key, value)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package lru
+
+import (
+	"testing"
+	"time"
+)
+
+// removal is one call of the remove callback.
+type removal struct {
+	key    string
+	value  int
+	reason RemoveReason
+}
+
+// TestSetOnRemove verifies each kind of removal reports its reason.
+func TestSetOnRemove(t *testing.T) {
+	clock := newFakeClock()
+	cache, _ := NewLRUWithOptions(WithCapacity[string, int](2), WithClock[string, int](clock))
+	var got []removal
+	cache.SetOnRemove(func(key string, value int, reason RemoveReason) {
+		got = append(got, removal{key, value, reason})
+	})
+	evicted := 0
+	cache.SetEvictionCallback(func(string, int) { evicted++ })
+
+	cache.Put("a", 1)
+	cache.Put("a", 2) // replaced
+	cache.Put("b", 3)
+	cache.Put("c", 4) // evicts a
+	cache.Remove("b")
+	cache.PutWithTTL("d", 5, time.Second)
+	clock.Advance(time.Second)
+	cache.Get("d") // expires d
+	cache.Purge()  // removes c
+
+	want := []removal{
+		{"a", 1, Replaced},
+		{"a", 2, Evicted},
+		{"b", 3, Manual},
+		{"d", 5, Expired},
+		{"c", 4, Manual},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("removal %d: expected %v, got %v", i, want[i], got[i])
+		}
+	}
+	if evicted != 3 { // eviction, expiry fallback and purge
+		t.Errorf("expected eviction callback to still be called 3 times, got %d", evicted)
+	}
+
+	cache.SetOnRemove(nil)
+	cache.Put("e", 6)
+	cache.Remove("e")
+	if len(got) != len(want) {
+		t.Errorf("expected no calls after unsetting, got %v", got[len(want):])
+	}
+}
+
+// TestRemoveReasonString verifies the names of the reasons.
+func TestRemoveReasonString(t *testing.T) {
+	for reason, want := range map[RemoveReason]string{
+		Evicted:  "Evicted",
+		Expired:  "Expired",
+		Replaced: "Replaced",
+		Manual:   "Manual",
+		-1:       "Unknown",
+	} {
+		if got := reason.String(); got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	}
+}
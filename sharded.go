@@ -0,0 +1,161 @@
+package lru
+
+import (
+	"errors"
+	"fmt"
+	"hash/maphash"
+)
+
+// ShardedLRU is a thread-safe cache that spreads keys across independent
+// LRU shards, each with its own lock, to reduce contention. Recency and
+// eviction are tracked per shard, so it only approximates a global LRU.
+type ShardedLRU[K comparable, V any] struct {
+	shards []*LRU[K, V]
+	seed   maphash.Seed
+	hasher func(K) uint64 // optional shard hash, overriding hash
+}
+
+// ShardedOption configures a cache created by NewShardedLRU.
+type ShardedOption[K comparable, V any] func(*ShardedLRU[K, V])
+
+// WithShardHasher sets the function used to pick a key's shard, which is
+// the hash modulo the shard count. By default strings and integers are
+// hashed directly and other keys by their fmt representation, so a custom
+// hasher is worthwhile for struct keys or keys with known distribution.
+func WithShardHasher[K comparable, V any](hasher func(K) uint64) ShardedOption[K, V] {
+	return func(s *ShardedLRU[K, V]) {
+		s.hasher = hasher
+	}
+}
+
+// NewShardedLRU creates a sharded cache with the given number of shards and
+// total capacity, split as evenly as possible across the shards.
+// Returns an error if shards <= 0 or capacity < shards.
+func NewShardedLRU[K comparable, V any](shards, capacity int, opts ...ShardedOption[K, V]) (*ShardedLRU[K, V], error) {
+	if shards <= 0 {
+		return nil, errors.New("shard count must be greater than 0")
+	}
+	if capacity < shards {
+		return nil, errors.New("capacity must be at least the shard count")
+	}
+	s := &ShardedLRU[K, V]{
+		shards: make([]*LRU[K, V], shards),
+		seed:   maphash.MakeSeed(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	for i := range s.shards {
+		n := capacity / shards
+		if i < capacity%shards {
+			n++
+		}
+		shard, err := NewLRU[K, V](n)
+		if err != nil {
+			return nil, err
+		}
+		s.shards[i] = shard
+	}
+	return s, nil
+}
+
+// Get retrieves the value for the given key from its shard if present.
+func (s *ShardedLRU[K, V]) Get(key K) (V, bool) {
+	return s.shard(key).Get(key)
+}
+
+// Put inserts or updates the value for the given key in its shard.
+// If the shard is full, evicts its least recently used item.
+func (s *ShardedLRU[K, V]) Put(key K, val V) {
+	s.shard(key).Put(key, val)
+}
+
+// Remove deletes the entry for the given key.
+// Returns true if the key was present.
+func (s *ShardedLRU[K, V]) Remove(key K) bool {
+	return s.shard(key).Remove(key)
+}
+
+// Contains reports whether the key is present without updating its recency.
+func (s *ShardedLRU[K, V]) Contains(key K) bool {
+	return s.shard(key).Contains(key)
+}
+
+// Len returns the total number of items across all shards.
+func (s *ShardedLRU[K, V]) Len() int {
+	n := 0
+	for _, shard := range s.shards {
+		n += shard.Len()
+	}
+	return n
+}
+
+// Stats returns the statistics of each shard, in shard order. Comparing
+// them shows whether keys or load are spread unevenly across shards. Use
+// TotalStats for the aggregate.
+func (s *ShardedLRU[K, V]) Stats() []Stats {
+	stats := make([]Stats, len(s.shards))
+	for i, shard := range s.shards {
+		stats[i] = shard.Stats()
+	}
+	return stats
+}
+
+// TotalStats returns the sum of the statistics of all shards.
+func (s *ShardedLRU[K, V]) TotalStats() Stats {
+	var total Stats
+	for _, st := range s.Stats() {
+		total.Hits += st.Hits
+		total.Misses += st.Misses
+		total.Evictions += st.Evictions
+		total.Len += st.Len
+	}
+	return total
+}
+
+// shard returns the shard responsible for key.
+func (s *ShardedLRU[K, V]) shard(key K) *LRU[K, V] {
+	return s.shards[s.shardIndex(key)]
+}
+
+// shardIndex returns the index of the shard responsible for key.
+func (s *ShardedLRU[K, V]) shardIndex(key K) int {
+	h := s.hasher
+	if h == nil {
+		h = s.hash
+	}
+	return int(h(key) % uint64(len(s.shards)))
+}
+
+// hash returns a hash of key. Strings and integers are hashed directly;
+// other key types are hashed by their fmt representation.
+func (s *ShardedLRU[K, V]) hash(key K) uint64 {
+	switch k := any(key).(type) {
+	case string:
+		return maphash.String(s.seed, k)
+	case int:
+		return mix(uint64(k))
+	case int64:
+		return mix(uint64(k))
+	case int32:
+		return mix(uint64(k))
+	case uint:
+		return mix(uint64(k))
+	case uint64:
+		return mix(k)
+	case uint32:
+		return mix(uint64(k))
+	default:
+		return maphash.String(s.seed, fmt.Sprint(key))
+	}
+}
+
+// mix scrambles the bits of x so that sequential integers spread evenly.
+func mix(x uint64) uint64 {
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
@@ -0,0 +1,144 @@
+package lru
+
+import (
+	"strconv"
+	"testing"
+)
+
+// TestShardedLRU verifies the sharded cache behaves like a bounded cache.
+func TestShardedLRU(t *testing.T) {
+	cache, err := NewShardedLRU[int, string](4, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	total := 0
+	for _, shard := range cache.shards {
+		total += shard.Cap()
+	}
+	if total != 10 {
+		t.Errorf("expected shard capacities to sum to 10, got %d", total)
+	}
+
+	for i := 0; i < 100; i++ {
+		cache.Put(i, strconv.Itoa(i))
+	}
+	if cache.Len() > 10 {
+		t.Errorf("expected at most 10 entries, got %d", cache.Len())
+	}
+
+	cache.Put(1000, "last")
+	if val, ok := cache.Get(1000); !ok || val != "last" {
+		t.Errorf("expected last, got %v", val)
+	}
+	if !cache.Remove(1000) {
+		t.Errorf("expected Remove(1000) to report true")
+	}
+	if _, ok := cache.Get(1000); ok {
+		t.Errorf("expected key 1000 to be removed")
+	}
+}
+
+// TestShardedLRUStringKeys verifies non-integer keys route consistently.
+func TestShardedLRUStringKeys(t *testing.T) {
+	cache, _ := NewShardedLRU[string, int](8, 64)
+
+	for i := 0; i < 32; i++ {
+		cache.Put("key"+strconv.Itoa(i), i)
+	}
+	for i := 0; i < 32; i++ {
+		if val, ok := cache.Get("key" + strconv.Itoa(i)); ok && val != i {
+			t.Errorf("expected %d, got %d", i, val)
+		}
+	}
+}
+
+// TestNewShardedLRUInvalid ensures invalid shard layouts are rejected.
+func TestNewShardedLRUInvalid(t *testing.T) {
+	if _, err := NewShardedLRU[int, int](0, 10); err == nil {
+		t.Errorf("expected error for zero shards")
+	}
+	if _, err := NewShardedLRU[int, int](4, 3); err == nil {
+		t.Errorf("expected error for capacity below shard count")
+	}
+}
+
+// BenchmarkSingleLockParallel measures a single LRU under parallel load.
+func BenchmarkSingleLockParallel(b *testing.B) {
+	cache, _ := NewLRU[int, int](1024)
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			cache.Put(i%2048, i)
+			cache.Get(i % 2048)
+			i++
+		}
+	})
+}
+
+// BenchmarkShardedParallel measures a sharded LRU under parallel load.
+func BenchmarkShardedParallel(b *testing.B) {
+	cache, _ := NewShardedLRU[int, int](16, 1024)
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			cache.Put(i%2048, i)
+			cache.Get(i % 2048)
+			i++
+		}
+	})
+}
+
+// TestShardedLRUStats verifies per-shard stats reveal a hot shard and the
+// total sums them.
+func TestShardedLRUStats(t *testing.T) {
+	cache, _ := NewShardedLRU[int, int](4, 40)
+	hot := 7
+	hotShard := cache.shardIndex(hot)
+
+	cache.Put(hot, 1)
+	for i := 0; i < 100; i++ {
+		cache.Get(hot)
+	}
+	cache.Get(-1)
+
+	stats := cache.Stats()
+	if len(stats) != 4 {
+		t.Fatalf("expected 4 shard stats, got %d", len(stats))
+	}
+	for i, st := range stats {
+		if i == hotShard && st.Hits != 100 {
+			t.Errorf("expected hot shard %d to have 100 hits, got %d", i, st.Hits)
+		}
+		if i != hotShard && st.Hits != 0 {
+			t.Errorf("expected shard %d to have no hits, got %d", i, st.Hits)
+		}
+	}
+
+	total := cache.TotalStats()
+	if total.Hits != 100 || total.Misses != 1 || total.Len != 1 {
+		t.Errorf("expected 100 hits, 1 miss, len 1, got %+v", total)
+	}
+}
+
+// TestShardedLRUHasher verifies a custom hasher routes keys.
+func TestShardedLRUHasher(t *testing.T) {
+	cache, err := NewShardedLRU(4, 40, WithShardHasher[int, int](func(int) uint64 { return 2 }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 8; i++ {
+		cache.Put(i, i)
+	}
+	for i, shard := range cache.shards {
+		if i == 2 && shard.Len() != 8 {
+			t.Errorf("expected all keys in shard 2, got %d", shard.Len())
+		}
+		if i != 2 && shard.Len() != 0 {
+			t.Errorf("expected shard %d to be empty, got %d", i, shard.Len())
+		}
+	}
+	if v, ok := cache.Get(5); !ok || v != 5 {
+		t.Errorf("expected 5, got %d, %v", v, ok)
+	}
+}
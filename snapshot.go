@@ -0,0 +1,91 @@
+package lru
+
+import "iter"
+
+// Entry is a key-value pair copied out of the cache. It is also the
+// serialized form of an entry used by Save and MarshalJSON.
+//
+// Seq is the entry's insertion sequence number: each key inserted into a
+// cache gets the next number, starting at 1, and keeps it when its value is
+// overwritten, so entries can be ordered by when they were first stored.
+// Removing a key and storing it again assigns a new number. Seq is not
+// encoded by MarshalJSON and is ignored by WarmUp, ReplaceAll and Load,
+// which number entries as they insert them.
+type Entry[K comparable, V any] struct {
+	Key   K      `json:"key"`
+	Value V      `json:"value"`
+	Seq   uint64 `json:"-"`
+}
+
+// Snapshot returns a copy of the live entries ordered from least to most
+// recently used, like Keys, taken atomically under the read lock. Later
+// changes to the cache do not affect the returned slice, which can be read
+// without locking. Values are copied shallowly: if V is a pointer, map or
+// slice, the snapshot shares the underlying data with the cache.
+func (c *LRU[K, V]) Snapshot() []Entry[K, V] {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	entries := make([]Entry[K, V], 0, c.list.Len())
+	for el := c.list.Back(); el != nil; el = el.Prev() {
+		kv := el.Value.(*entry[K, V])
+		if !c.live(kv) {
+			continue
+		}
+		entries = append(entries, Entry[K, V]{Key: kv.key, Value: kv.val, Seq: kv.seq})
+	}
+	return entries
+}
+
+// All returns an iterator over the live entries from most to least recently
+// used, for use with range:
+//
+//	for k, v := range cache.All() {
+//		...
+//	}
+//
+// The entries are copied under the read lock when iteration starts, so the
+// loop body may call back into the cache, and changes made during the loop
+// are not seen by it. Iterating does not affect recency.
+func (c *LRU[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		entries := c.Snapshot()
+		for i := len(entries) - 1; i >= 0; i-- {
+			if !yield(entries[i].Key, entries[i].Value) {
+				return
+			}
+		}
+	}
+}
+
+// WarmUp inserts entries in order, so the last one ends up most recently
+// used, under a single lock acquisition. Existing entries are kept but
+// become less recently used than the new ones, and overwritten keys take
+// their position from entries. If there are more entries than the capacity,
+// the earliest are evicted as they load: with LRUPolicy and distinct keys
+// the survivors are always the last entries of the slice, and the eviction
+// callback sees the others in slice order. Entries get the default TTL.
+// WarmUp restores the order returned by Snapshot.
+func (c *LRU[K, V]) WarmUp(entries []Entry[K, V]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.warmUp(entries)
+}
+
+// ReplaceAll replaces the contents of the cache with entries in a single
+// step, so readers see either the old contents or the new ones and never a
+// mix. The eviction callback is called for each old entry as by Purge, then
+// entries are inserted as by WarmUp.
+func (c *LRU[K, V]) ReplaceAll(entries []Entry[K, V]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.purge()
+	c.warmUp(entries)
+}
+
+// warmUp inserts entries from least to most recently used.
+// Caller must hold the write lock.
+func (c *LRU[K, V]) warmUp(entries []Entry[K, V]) {
+	for _, e := range entries {
+		c.put(e.Key, e.Value, c.ttl, c.sizeOf(e.Key, e.Value))
+	}
+}
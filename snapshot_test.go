@@ -0,0 +1,280 @@
+package lru
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestSnapshot verifies Snapshot returns live entries in recency order.
+func TestSnapshot(t *testing.T) {
+	cache, _ := NewLRU[string, int](3)
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+	cache.Get("a")
+
+	snap := cache.Snapshot()
+	want := []Entry[string, int]{{"b", 2, 2}, {"c", 3, 3}, {"a", 1, 1}}
+	if len(snap) != len(want) {
+		t.Fatalf("expected %v, got %v", want, snap)
+	}
+	for i := range want {
+		if snap[i] != want[i] {
+			t.Errorf("expected %v at %d, got %v", want[i], i, snap[i])
+		}
+	}
+}
+
+// TestSnapshotSeq verifies insertion sequence numbers increase with each
+// new key and are kept across reads and overwrites.
+func TestSnapshotSeq(t *testing.T) {
+	cache, _ := NewLRU[string, int](3)
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+	seqs := func() map[string]uint64 {
+		m := make(map[string]uint64)
+		for _, e := range cache.Snapshot() {
+			m[e.Key] = e.Seq
+		}
+		return m
+	}
+	before := seqs()
+	if !(0 < before["a"] && before["a"] < before["b"] && before["b"] < before["c"]) {
+		t.Fatalf("expected increasing sequence numbers, got %v", before)
+	}
+
+	cache.Get("a")
+	cache.Put("b", 20)
+	if after := seqs(); after["a"] != before["a"] || after["b"] != before["b"] {
+		t.Errorf("expected sequence numbers to be kept, got %v then %v", before, after)
+	}
+
+	cache.Remove("a")
+	cache.Put("a", 1)
+	cache.Put("d", 4) // evicts c
+	after := seqs()
+	if after["a"] <= before["c"] || after["d"] <= after["a"] {
+		t.Errorf("expected new numbers for reinserted and new keys, got %v", after)
+	}
+	if clone, _ := cache.Clone(); clone.Snapshot()[0].Seq != cache.Snapshot()[0].Seq {
+		t.Error("expected Clone to keep sequence numbers")
+	}
+}
+
+// TestSnapshotStable verifies later mutations do not affect a snapshot.
+func TestSnapshotStable(t *testing.T) {
+	cache, _ := NewLRU[string, int](2)
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+
+	snap := cache.Snapshot()
+	cache.Put("a", 10)
+	cache.Put("c", 3)
+	cache.Remove("a")
+	cache.Clear()
+
+	if len(snap) != 2 || snap[0] != (Entry[string, int]{"a", 1, 1}) || snap[1] != (Entry[string, int]{"b", 2, 2}) {
+		t.Errorf("expected [{a 1} {b 2}], got %v", snap)
+	}
+}
+
+// TestSnapshotSkipsExpired verifies expired entries are left out.
+func TestSnapshotSkipsExpired(t *testing.T) {
+	clock := newFakeClock()
+	cache, _ := NewLRUWithOptions(WithCapacity[int, string](3), WithClock[int, string](clock))
+	cache.PutWithTTL(1, "short", time.Second)
+	cache.Put(2, "long")
+
+	clock.Advance(2 * time.Second)
+	snap := cache.Snapshot()
+	if len(snap) != 1 || snap[0].Key != 2 {
+		t.Errorf("expected only key 2, got %v", snap)
+	}
+}
+
+// TestAll verifies All yields entries in recency order and stops on break.
+func TestAll(t *testing.T) {
+	cache, _ := NewLRU[string, int](3)
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+
+	var keys []string
+	sum := 0
+	for k, v := range cache.All() {
+		keys = append(keys, k)
+		sum += v
+	}
+	if len(keys) != 3 || keys[0] != "c" || keys[1] != "b" || keys[2] != "a" || sum != 6 {
+		t.Errorf("expected [c b a] summing to 6, got %v summing to %d", keys, sum)
+	}
+
+	n := 0
+	for range cache.All() {
+		n++
+		break
+	}
+	if n != 1 {
+		t.Errorf("expected iteration to stop after 1, got %d", n)
+	}
+}
+
+// TestAllCallsBack verifies the loop body may modify the cache.
+func TestAllCallsBack(t *testing.T) {
+	cache, _ := NewLRU[string, int](3)
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+
+	n := 0
+	for k := range cache.All() {
+		cache.Remove(k)
+		cache.Put(k+"x", 0)
+		n++
+	}
+	if n != 2 {
+		t.Errorf("expected 2 iterations, got %d", n)
+	}
+	if cache.Contains("a") || cache.Contains("b") {
+		t.Error("expected removals made during iteration to apply")
+	}
+}
+
+// TestWarmUp verifies a Snapshot round trip through WarmUp keeps the order.
+func TestWarmUp(t *testing.T) {
+	cache, _ := NewLRU[string, int](3)
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+	cache.Get("a")
+	snap := cache.Snapshot()
+
+	restored, _ := NewLRU[string, int](3)
+	restored.WarmUp(snap)
+	got := restored.Snapshot()
+	if len(got) != len(snap) {
+		t.Fatalf("expected %v, got %v", snap, got)
+	}
+	for i := range snap { // sequence numbers are reassigned on insert
+		if got[i].Key != snap[i].Key || got[i].Value != snap[i].Value {
+			t.Errorf("expected %v, got %v", snap, got)
+			break
+		}
+	}
+}
+
+// TestWarmUpOverCapacity verifies the earliest entries are evicted when
+// there are more entries than the capacity.
+func TestWarmUpOverCapacity(t *testing.T) {
+	cache, _ := NewLRU[string, int](2)
+	cache.Put("x", 0)
+	cache.WarmUp([]Entry[string, int]{{Key: "a", Value: 1}, {Key: "b", Value: 2}, {Key: "c", Value: 3}})
+	keys := cache.Keys()
+	if len(keys) != 2 || keys[0] != "b" || keys[1] != "c" {
+		t.Errorf("expected [b c], got %v", keys)
+	}
+}
+
+// TestWarmUpEvictionOrder verifies loading twice the capacity keeps exactly
+// the last entries and evicts the rest in input order.
+func TestWarmUpEvictionOrder(t *testing.T) {
+	cache, _ := NewLRU[int, int](4)
+	var evicted []int
+	cache.SetEvictionCallback(func(k, v int) { evicted = append(evicted, k) })
+	entries := make([]Entry[int, int], 8)
+	for i := range entries {
+		entries[i] = Entry[int, int]{Key: 10 - i, Value: i} // keys descend so order is not key order
+	}
+
+	cache.WarmUp(entries)
+	keys := cache.Keys()
+	if want := []int{6, 5, 4, 3}; fmt.Sprint(keys) != fmt.Sprint(want) {
+		t.Errorf("expected survivors %v, got %v", want, keys)
+	}
+	if want := []int{10, 9, 8, 7}; fmt.Sprint(evicted) != fmt.Sprint(want) {
+		t.Errorf("expected evictions %v, got %v", want, evicted)
+	}
+}
+
+// TestReplaceAll verifies the old entries are evicted and the new ones
+// loaded in order.
+func TestReplaceAll(t *testing.T) {
+	cache, _ := NewLRU[string, int](3)
+	var evicted []string
+	cache.SetEvictionCallback(func(k string, v int) { evicted = append(evicted, k) })
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+
+	cache.ReplaceAll([]Entry[string, int]{{Key: "x", Value: 10}, {Key: "y", Value: 20}})
+	keys := cache.Keys()
+	if len(keys) != 2 || keys[0] != "x" || keys[1] != "y" {
+		t.Errorf("expected [x y], got %v", keys)
+	}
+	if len(evicted) != 2 || evicted[0] != "a" || evicted[1] != "b" {
+		t.Errorf("expected callbacks for [a b], got %v", evicted)
+	}
+}
+
+// TestReplaceAllAtomic verifies concurrent readers only see the old or the
+// new contents in full.
+func TestReplaceAllAtomic(t *testing.T) {
+	const n = 50
+	old := make([]Entry[int, int], n)
+	fresh := make([]Entry[int, int], n)
+	for i := range old {
+		old[i] = Entry[int, int]{Key: i, Value: 0}
+		fresh[i] = Entry[int, int]{Key: i + n, Value: 1}
+	}
+	cache, _ := NewLRU[int, int](n)
+	cache.WarmUp(old)
+
+	stop := make(chan struct{})
+	errs := make(chan string, 1)
+	var wg sync.WaitGroup
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				snap := cache.Snapshot()
+				if len(snap) != n {
+					select {
+					case errs <- fmt.Sprintf("saw %d entries", len(snap)):
+					default:
+					}
+					return
+				}
+				for _, e := range snap {
+					if e.Value != snap[0].Value {
+						select {
+						case errs <- "saw a mix of old and new entries":
+						default:
+						}
+						return
+					}
+				}
+			}
+		}()
+	}
+	for i := 0; i < 100; i++ {
+		if i%2 == 0 {
+			cache.ReplaceAll(fresh)
+		} else {
+			cache.ReplaceAll(old)
+		}
+	}
+	close(stop)
+	wg.Wait()
+	select {
+	case msg := <-errs:
+		t.Error(msg)
+	default:
+	}
+}
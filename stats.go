@@ -0,0 +1,86 @@
+package lru
+
+import "time"
+
+// Stats is a point-in-time snapshot of cache counters.
+type Stats struct {
+	Hits      uint64 // Get calls that found a live entry
+	Misses    uint64 // Get calls that found nothing
+	Evictions uint64 // entries evicted to stay within capacity
+	Len       int    // current number of items
+}
+
+// Stats returns the current hit, miss and eviction counters and length.
+// The counters are read atomically but not as a single consistent snapshot.
+func (c *LRU[K, V]) Stats() Stats {
+	return Stats{
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Evictions: c.evictions.Load(),
+		Len:       c.Len(),
+	}
+}
+
+// ResetStats zeroes the hit, miss and eviction counters.
+// Cached data is not affected.
+func (c *LRU[K, V]) ResetStats() {
+	c.hits.Store(0)
+	c.misses.Store(0)
+	c.evictions.Store(0)
+}
+
+// EvictionCount returns the number of entries evicted to stay within
+// capacity since the cache was created or the stats were last reset. It
+// counts evictions whether or not an eviction callback is set.
+func (c *LRU[K, V]) EvictionCount() uint64 {
+	return c.evictions.Load()
+}
+
+// statsReporter periodically passes a cache's stats to a callback.
+type statsReporter struct {
+	quit chan struct{}
+	done chan struct{}
+}
+
+// SetStatsCallback starts a background goroutine that calls fn with the
+// current Stats every interval, replacing any callback already set. Passing
+// a nil fn or an interval <= 0 stops the callback; SetStatsCallback returns
+// once the previous goroutine has exited, so fn is never called after a
+// stopping call returns. fn runs without the cache lock held.
+func (c *LRU[K, V]) SetStatsCallback(interval time.Duration, fn func(Stats)) {
+	c.mu.Lock()
+	// As in StartJanitor, stop reporters until none is left and install the
+	// new one under the same lock, so concurrent calls cannot orphan one.
+	for c.reporter != nil {
+		old := c.reporter
+		c.reporter = nil
+		c.mu.Unlock()
+		close(old.quit)
+		<-old.done
+		c.mu.Lock()
+	}
+	if fn == nil || interval <= 0 {
+		c.mu.Unlock()
+		return
+	}
+	r := &statsReporter{
+		quit: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	c.reporter = r
+	c.mu.Unlock()
+
+	go func() {
+		defer close(r.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				fn(c.Stats())
+			case <-r.quit:
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,140 @@
+package lru
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestStats verifies hit, miss and eviction counters.
+func TestStats(t *testing.T) {
+	cache, _ := NewLRU[int, string](2)
+
+	cache.Put(1, "one")
+	cache.Put(2, "two")
+	cache.Get(1)          // hit
+	cache.Get(3)          // miss
+	cache.Put(3, "three") // evicts 2
+	cache.Put(4, "four")  // evicts 1
+	cache.Get(2)          // miss
+	cache.Get(4)          // hit
+	cache.Peek(4)         // not counted
+
+	want := Stats{Hits: 2, Misses: 2, Evictions: 2, Len: 2}
+	if got := cache.Stats(); got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
+
+// TestResetStats verifies only activity after a reset is counted.
+func TestResetStats(t *testing.T) {
+	cache, _ := NewLRU[int, string](1)
+
+	cache.Put(1, "one")
+	cache.Get(1)
+	cache.Get(2)
+	cache.Put(2, "two")
+
+	cache.ResetStats()
+	if got := cache.Stats(); got != (Stats{Len: 1}) {
+		t.Errorf("expected zeroed counters, got %+v", got)
+	}
+
+	cache.Get(2)
+	cache.Put(3, "three")
+
+	want := Stats{Hits: 1, Evictions: 1, Len: 1}
+	if got := cache.Stats(); got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
+
+// TestEvictionCount verifies every capacity-driven eviction is counted.
+func TestEvictionCount(t *testing.T) {
+	cache, _ := NewLRU[int, int](3)
+
+	for i := 0; i < 10; i++ {
+		cache.Put(i, i)
+	}
+	cache.Remove(9) // not an eviction
+
+	if n := cache.EvictionCount(); n != 7 {
+		t.Errorf("expected 7 evictions, got %d", n)
+	}
+}
+
+// TestSetStatsCallback verifies the callback fires periodically with
+// non-decreasing counters and stops when cleared.
+func TestSetStatsCallback(t *testing.T) {
+	cache, _ := NewLRU[int, string](2)
+	cache.Put(1, "one")
+
+	var mu sync.Mutex
+	var seen []Stats
+	cache.SetStatsCallback(time.Millisecond, func(s Stats) {
+		mu.Lock()
+		seen = append(seen, s)
+		mu.Unlock()
+	})
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		cache.Get(1)
+		mu.Lock()
+		n := len(seen)
+		mu.Unlock()
+		if n >= 3 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	cache.SetStatsCallback(0, nil)
+
+	mu.Lock()
+	n := len(seen)
+	for i := 1; i < n; i++ {
+		if seen[i].Hits < seen[i-1].Hits {
+			t.Errorf("expected non-decreasing hits, got %d after %d", seen[i].Hits, seen[i-1].Hits)
+		}
+	}
+	mu.Unlock()
+	if n < 3 {
+		t.Fatalf("expected at least 3 callbacks, got %d", n)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	if len(seen) != n {
+		t.Errorf("expected no callbacks after stopping, got %d more", len(seen)-n)
+	}
+}
+
+// TestSetStatsCallbackConcurrent verifies concurrent SetStatsCallback calls
+// leave a single reporter, so a stopping call stops every goroutine they
+// started.
+func TestSetStatsCallbackConcurrent(t *testing.T) {
+	cache, _ := NewLRU[int, int](4)
+	before := runtime.NumGoroutine()
+	for trial := 0; trial < 2000; trial++ {
+		var wg sync.WaitGroup
+		for i := 0; i < 32; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				cache.SetStatsCallback(time.Hour, func(Stats) {})
+			}()
+		}
+		wg.Wait()
+	}
+	cache.SetStatsCallback(0, nil)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if n := runtime.NumGoroutine(); n > before {
+		t.Errorf("expected all reporters to stop, %d goroutines left over", n-before)
+	}
+}
@@ -0,0 +1,191 @@
+package lru
+
+import (
+	"container/list"
+	"time"
+)
+
+// NewLRUWithTTL creates a new LRU cache with the specified capacity whose
+// entries expire after ttl unless stored with PutWithTTL.
+// A ttl <= 0 means entries stored with Put never expire.
+// Returns an error if capacity <= 0.
+func NewLRUWithTTL[K comparable, V any](capacity int, ttl time.Duration) (*LRU[K, V], error) {
+	return NewLRUWithOptions(WithCapacity[K, V](capacity), WithDefaultTTL[K, V](ttl))
+}
+
+// NoExpiration is the remaining TTL reported for entries that never expire.
+const NoExpiration time.Duration = -1
+
+// PutWithTTL inserts or updates the value for the given key and expires it
+// after ttl, overriding the cache's default TTL. A ttl <= 0 means the entry
+// never expires.
+// If capacity is exceeded, evicts the least recently used item.
+func (c *LRU[K, V]) PutWithTTL(key K, val V, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.put(key, val, ttl, c.sizeOf(key, val))
+}
+
+// now returns the current time according to the cache's clock.
+func (c *LRU[K, V]) now() time.Time {
+	return c.clock.Now()
+}
+
+// deadline returns the expiry time for an entry stored now with the given
+// ttl, or the zero time if ttl <= 0.
+func (c *LRU[K, V]) deadline(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return c.now().Add(ttl)
+}
+
+// expired reports whether kv has passed its deadline.
+// Caller must hold the lock.
+func (c *LRU[K, V]) expired(kv *entry[K, V]) bool {
+	return !kv.expires.IsZero() && !c.now().Before(kv.expires)
+}
+
+// GetWithTTL retrieves the value for the given key like Get, along with the
+// time remaining until it expires. Entries that never expire report
+// NoExpiration.
+func (c *LRU[K, V]) GetWithTTL(key K) (V, time.Duration, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	val, ok := c.get(key)
+	if !ok {
+		return val, 0, false
+	}
+	kv := c.idx[key].Value.(*entry[K, V])
+	if kv.expires.IsZero() {
+		return val, NoExpiration, true
+	}
+	return val, kv.expires.Sub(c.now()), true
+}
+
+// GetAllowStale retrieves the value for the given key like Get, but also
+// returns an entry that expired less than maxStale ago, reporting it as
+// stale instead of removing it. A stale entry is promoted and counted as a
+// hit, and if refresh-ahead is enabled a reload is started, so a caller can
+// keep serving the old value while the new one loads. Entries further past
+// their deadline are removed and reported as absent. Expired entries may
+// still be removed by the janitor or other lookups before maxStale passes.
+func (c *LRU[K, V]) GetAllowStale(key K, maxStale time.Duration) (value V, stale bool, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, found := c.idx[key]; found {
+		kv := el.Value.(*entry[K, V])
+		if c.expired(kv) && !kv.negative && c.now().Before(kv.expires.Add(maxStale)) {
+			c.touchRead(el)
+			c.hits.Add(1)
+			c.sampleLookup(true)
+			c.maybeRefresh(kv)
+			return c.read(kv), true, true
+		}
+	}
+	value, ok = c.get(key)
+	return value, false, ok
+}
+
+// Touch marks the entry for key as used and, if it has a TTL, restarts it
+// with the TTL it was stored with. Returns false if the key is absent or
+// already expired.
+func (c *LRU[K, V]) Touch(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.idx[key]
+	if !ok {
+		return false
+	}
+	kv := el.Value.(*entry[K, V])
+	if c.expired(kv) {
+		c.expire(el)
+		return false
+	}
+	c.touch(el)
+	kv.expires = c.deadline(kv.ttl)
+	return true
+}
+
+// LiveLen returns the number of entries that have not expired, leaving out
+// negative entries stored with PutNegative. Unlike Len, which includes
+// expired and negative entries, it scans every entry.
+func (c *LRU[K, V]) LiveLen() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	n := 0
+	for el := c.list.Front(); el != nil; el = el.Next() {
+		if c.live(el.Value.(*entry[K, V])) {
+			n++
+		}
+	}
+	return n
+}
+
+// ExpiredKeys returns the keys of entries whose TTL has passed but that
+// have not been removed yet, ordered from least to most recently used. The
+// cache is not modified. It returns nil if no entry has expired.
+func (c *LRU[K, V]) ExpiredKeys() []K {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var keys []K
+	for el := c.list.Back(); el != nil; el = el.Prev() {
+		if kv := el.Value.(*entry[K, V]); c.expired(kv) {
+			keys = append(keys, kv.key)
+		}
+	}
+	return keys
+}
+
+// SetExpirationCallback sets the callback to be called when an item is
+// removed because its TTL passed. If unset, the eviction callback is used.
+func (c *LRU[K, V]) SetExpirationCallback(fn func(key K, value V)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onExpire = fn
+}
+
+// expire removes an expired element and notifies the expiration callback,
+// falling back to the eviction callback. Caller must hold the write lock.
+func (c *LRU[K, V]) expire(el *list.Element) {
+	kv := c.removeElement(el)
+	switch {
+	case c.onExpire != nil:
+		c.onExpire(kv.key, kv.val)
+	default:
+		c.notifyEvict(kv.key, kv.val)
+	}
+	c.notifyRemove(kv.key, kv.val, Expired)
+}
+
+// peekRefresh implements Peek for caches created with WithPeekRefreshesTTL,
+// restarting the TTL of a live entry without changing its recency.
+func (c *LRU[K, V]) peekRefresh(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var zero V
+	el, ok := c.idx[key]
+	if !ok {
+		return zero, false
+	}
+	kv := el.Value.(*entry[K, V])
+	if c.expired(kv) {
+		c.expire(el)
+		return zero, false
+	}
+	if kv.negative {
+		return zero, false
+	}
+	kv.expires = c.deadline(kv.ttl)
+	return c.read(kv), true
+}
+
+// removeExpired takes the write lock and removes key if it is still present
+// and expired. It is used by read paths that only hold the read lock.
+func (c *LRU[K, V]) removeExpired(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.idx[key]; ok && c.expired(el.Value.(*entry[K, V])) {
+		c.expire(el)
+	}
+}
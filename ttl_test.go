@@ -0,0 +1,422 @@
+package lru
+
+import (
+	"testing"
+	"time"
+)
+
+// TestPutWithTTL verifies entries are served before expiry and missed after.
+func TestPutWithTTL(t *testing.T) {
+	clock := newFakeClock()
+	cache, _ := NewLRUWithOptions(WithCapacity[int, string](2), WithClock[int, string](clock))
+
+	cache.PutWithTTL(1, "one", time.Minute)
+	cache.Put(2, "two")
+
+	clock.Advance(59 * time.Second)
+	if val, ok := cache.Get(1); !ok || val != "one" {
+		t.Errorf("expected one before expiry, got %v", val)
+	}
+
+	clock.Advance(time.Second)
+	if _, ok := cache.Get(1); ok {
+		t.Errorf("expected key 1 to be expired")
+	}
+	if _, ok := cache.idx[1]; ok {
+		t.Errorf("expected expired key 1 to be removed from the index")
+	}
+	if cache.Len() != 1 {
+		t.Errorf("expected len 1, got %d", cache.Len())
+	}
+
+	clock.Advance(time.Hour)
+	if val, ok := cache.Get(2); !ok || val != "two" {
+		t.Errorf("expected entry stored with Put never to expire, got %v", val)
+	}
+}
+
+// TestPeekExpired verifies Peek treats expired entries as absent and removes them.
+func TestPeekExpired(t *testing.T) {
+	clock := newFakeClock()
+	cache, _ := NewLRUWithOptions(WithCapacity[int, string](2), WithClock[int, string](clock))
+	expired := 0
+	cache.SetEvictionCallback(func(k int, v string) {
+		expired++
+	})
+
+	cache.PutWithTTL(1, "one", time.Second)
+	if val, ok := cache.Peek(1); !ok || val != "one" {
+		t.Errorf("expected one before expiry, got %v", val)
+	}
+
+	clock.Advance(time.Second)
+	if _, ok := cache.Peek(1); ok {
+		t.Errorf("expected key 1 to be expired")
+	}
+	if cache.Len() != 0 {
+		t.Errorf("expected expired key to be removed, got len %d", cache.Len())
+	}
+	if expired != 1 {
+		t.Errorf("expected callback to fire once for expired key, got %d", expired)
+	}
+}
+
+// TestDefaultTTL verifies the default TTL applies to Put and can be overridden.
+func TestDefaultTTL(t *testing.T) {
+	clock := newFakeClock()
+	cache, err := NewLRUWithTTL[int, string](3, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache.clock = clock
+
+	cache.Put(1, "one")
+	cache.PutWithTTL(2, "two", time.Hour)
+	cache.PutWithTTL(3, "three", 0)
+
+	clock.Advance(time.Minute)
+	if _, ok := cache.Get(1); ok {
+		t.Errorf("expected key 1 to expire after the default TTL")
+	}
+	if !cache.Contains(2) {
+		t.Errorf("expected PutWithTTL to override the default TTL")
+	}
+
+	clock.Advance(time.Hour)
+	if cache.Contains(2) {
+		t.Errorf("expected key 2 to expire after its own TTL")
+	}
+	if !cache.Contains(3) {
+		t.Errorf("expected key 3 never to expire")
+	}
+}
+
+// TestZeroDefaultTTL verifies a zero default TTL means no expiry.
+func TestZeroDefaultTTL(t *testing.T) {
+	clock := newFakeClock()
+	cache, _ := NewLRUWithTTL[int, string](2, 0)
+	cache.clock = clock
+
+	cache.Put(1, "one")
+	clock.Advance(24 * time.Hour)
+
+	if val, ok := cache.Get(1); !ok || val != "one" {
+		t.Errorf("expected one, got %v", val)
+	}
+}
+
+// TestNewLRUWithTTLInvalidCapacity ensures the TTL constructor validates capacity.
+func TestNewLRUWithTTLInvalidCapacity(t *testing.T) {
+	if _, err := NewLRUWithTTL[int, string](0, time.Minute); err == nil {
+		t.Errorf("expected error for zero capacity cache")
+	}
+}
+
+// TestExpirationCallback verifies expiry and eviction fire separate callbacks.
+func TestExpirationCallback(t *testing.T) {
+	clock := newFakeClock()
+	cache, _ := NewLRUWithOptions(WithCapacity[int, string](2), WithClock[int, string](clock))
+	var evicted, expired []int
+	cache.SetEvictionCallback(func(k int, v string) {
+		evicted = append(evicted, k)
+	})
+	cache.SetExpirationCallback(func(k int, v string) {
+		expired = append(expired, k)
+	})
+
+	cache.PutWithTTL(1, "one", time.Second)
+	cache.Put(2, "two")
+	cache.Put(3, "three") // evicts key 1 by capacity
+
+	cache.PutWithTTL(4, "four", time.Second) // evicts key 2 by capacity
+	clock.Advance(time.Second)
+	cache.Get(4) // expires key 4
+
+	if len(evicted) != 2 || evicted[0] != 1 || evicted[1] != 2 {
+		t.Errorf("expected evictions [1 2], got %v", evicted)
+	}
+	if len(expired) != 1 || expired[0] != 4 {
+		t.Errorf("expected expirations [4], got %v", expired)
+	}
+}
+
+// TestExpirationFallback verifies expiry uses the eviction callback when no
+// expiration callback is set.
+func TestExpirationFallback(t *testing.T) {
+	clock := newFakeClock()
+	cache, _ := NewLRUWithOptions(WithCapacity[int, string](2), WithClock[int, string](clock))
+	evicted := 0
+	cache.SetEvictionCallback(func(k int, v string) {
+		evicted++
+	})
+
+	cache.PutWithTTL(1, "one", time.Second)
+	clock.Advance(time.Second)
+	cache.Get(1)
+
+	if evicted != 1 {
+		t.Errorf("expected eviction callback fallback, got %d calls", evicted)
+	}
+}
+
+// TestGetWithTTL verifies the remaining TTL shrinks as the clock advances.
+func TestGetWithTTL(t *testing.T) {
+	clock := newFakeClock()
+	cache, _ := NewLRUWithOptions(WithCapacity[int, string](2), WithClock[int, string](clock))
+
+	cache.PutWithTTL(1, "one", time.Minute)
+	cache.Put(2, "two")
+
+	if val, ttl, ok := cache.GetWithTTL(1); !ok || val != "one" || ttl != time.Minute {
+		t.Errorf("expected one with 1m left, got %v %v %v", val, ttl, ok)
+	}
+	clock.Advance(45 * time.Second)
+	if _, ttl, _ := cache.GetWithTTL(1); ttl != 15*time.Second {
+		t.Errorf("expected 15s left, got %v", ttl)
+	}
+	if _, ttl, ok := cache.GetWithTTL(2); !ok || ttl != NoExpiration {
+		t.Errorf("expected NoExpiration for key 2, got %v", ttl)
+	}
+
+	clock.Advance(15 * time.Second)
+	if _, _, ok := cache.GetWithTTL(1); ok {
+		t.Errorf("expected key 1 to be expired")
+	}
+	if _, _, ok := cache.GetWithTTL(3); ok {
+		t.Errorf("expected miss for key 3")
+	}
+}
+
+// TestGetAllowStale verifies fresh, stale and too-stale lookups.
+func TestGetAllowStale(t *testing.T) {
+	clock := newFakeClock()
+	cache, _ := NewLRUWithOptions(WithCapacity[int, string](2), WithClock[int, string](clock))
+	cache.PutWithTTL(1, "one", time.Minute)
+
+	if val, stale, ok := cache.GetAllowStale(1, 30*time.Second); !ok || stale || val != "one" {
+		t.Errorf("expected fresh one, got %v %v %v", val, stale, ok)
+	}
+	clock.Advance(time.Minute + 10*time.Second)
+	if val, stale, ok := cache.GetAllowStale(1, 30*time.Second); !ok || !stale || val != "one" {
+		t.Errorf("expected stale one, got %v %v %v", val, stale, ok)
+	}
+	if _, ok := cache.Get(1); ok {
+		t.Error("expected Get to treat the stale entry as expired")
+	}
+
+	cache.PutWithTTL(1, "one", time.Minute)
+	clock.Advance(time.Minute + 30*time.Second)
+	if _, stale, ok := cache.GetAllowStale(1, 30*time.Second); ok || stale {
+		t.Errorf("expected a miss beyond the stale window, got %v %v", stale, ok)
+	}
+	if cache.Len() != 0 {
+		t.Errorf("expected the entry to be removed, got %v", cache.Keys())
+	}
+	if _, stale, ok := cache.GetAllowStale(2, time.Minute); ok || stale {
+		t.Errorf("expected a miss for an absent key, got %v %v", stale, ok)
+	}
+}
+
+// TestGetAllowStaleRefresh verifies a stale read starts a refresh-ahead
+// reload that brings the entry back.
+func TestGetAllowStaleRefresh(t *testing.T) {
+	clock := newFakeClock()
+	loaded := make(chan struct{})
+	cache, _ := NewLRUWithOptions(
+		WithCapacity[int, string](2),
+		WithClock[int, string](clock),
+		WithRefreshAhead(0.8, func(k int) (string, error) {
+			defer close(loaded)
+			return "new", nil
+		}),
+	)
+	cache.PutWithTTL(1, "old", time.Minute)
+	clock.Advance(time.Minute)
+
+	if val, stale, ok := cache.GetAllowStale(1, time.Minute); !ok || !stale || val != "old" {
+		t.Errorf("expected stale old, got %v %v %v", val, stale, ok)
+	}
+	<-loaded
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) && cache.LiveLen() == 0 { // LiveLen never removes the stale entry
+		time.Sleep(time.Millisecond)
+	}
+	if val, ok := cache.Get(1); !ok || val != "new" {
+		t.Errorf("expected refreshed value, got %v %v", val, ok)
+	}
+}
+
+// TestTouch verifies Touch reorders recency and pushes out the deadline.
+func TestTouch(t *testing.T) {
+	clock := newFakeClock()
+	cache, _ := NewLRUWithOptions(WithCapacity[int, string](2), WithClock[int, string](clock))
+
+	if cache.Touch(1) {
+		t.Errorf("expected Touch on absent key to fail")
+	}
+
+	cache.PutWithTTL(1, "one", time.Minute)
+	cache.Put(2, "two")
+
+	clock.Advance(50 * time.Second)
+	if !cache.Touch(1) {
+		t.Errorf("expected Touch on present key to succeed")
+	}
+
+	clock.Advance(50 * time.Second) // past the original deadline
+	if _, ttl, ok := cache.GetWithTTL(1); !ok || ttl != 10*time.Second {
+		t.Errorf("expected touched key to have 10s left, got %v %v", ttl, ok)
+	}
+
+	cache.Touch(2)
+	cache.Touch(1)
+	cache.Put(3, "three") // key 2 is least recently touched
+	if cache.Contains(2) || !cache.Contains(1) {
+		t.Errorf("expected key 2 to be evicted, got %v", cache.Keys())
+	}
+
+	clock.Advance(time.Minute)
+	if cache.Touch(1) {
+		t.Errorf("expected Touch on expired key to fail")
+	}
+}
+
+// TestExpireBeforeEvict verifies a full cache drops expired entries before
+// evicting a live one.
+func TestExpireBeforeEvict(t *testing.T) {
+	clock := newFakeClock()
+	var evicted, expired []int
+	cache, _ := NewLRUWithOptions(
+		WithCapacity[int, string](3),
+		WithClock[int, string](clock),
+		WithExpireBeforeEvict[int, string](),
+		WithEvictionCallback(func(k int, v string) { evicted = append(evicted, k) }),
+		WithExpirationCallback(func(k int, v string) { expired = append(expired, k) }),
+	)
+	cache.Put(1, "live")
+	cache.PutWithTTL(2, "stale", time.Second)
+	cache.Put(3, "live")
+
+	clock.Advance(2 * time.Second)
+	cache.Put(4, "new")
+	if !cache.Contains(1) || !cache.Contains(3) || !cache.Contains(4) {
+		t.Errorf("expected live keys to survive, got %v", cache.Keys())
+	}
+	if len(evicted) != 0 || len(expired) != 1 || expired[0] != 2 {
+		t.Errorf("expected only key 2 expired, got evicted %v expired %v", evicted, expired)
+	}
+}
+
+// TestExpireBeforeEvictCost verifies expired entries are reclaimed first
+// when the cost budget is exceeded.
+func TestExpireBeforeEvictCost(t *testing.T) {
+	clock := newFakeClock()
+	cache, _ := NewLRUWithOptions(
+		WithCapacity[int, string](10),
+		WithMaxCost[int, string](10, func(k int, v string) int64 { return int64(len(v)) }),
+		WithClock[int, string](clock),
+		WithExpireBeforeEvict[int, string](),
+	)
+	cache.Put(1, "aaaa")
+	cache.PutWithTTL(2, "bbbb", time.Second)
+
+	clock.Advance(2 * time.Second)
+	cache.Put(3, "cccc")
+	if !cache.Contains(1) || !cache.Contains(3) || cache.Cost() != 8 {
+		t.Errorf("expected keys 1 and 3 with cost 8, got %v cost %d", cache.Keys(), cache.Cost())
+	}
+	if cache.EvictionCount() != 0 {
+		t.Errorf("expected no evictions, got %d", cache.EvictionCount())
+	}
+}
+
+// TestPeekRefreshesTTL verifies Peek extends expiry without changing the
+// eviction order when enabled.
+func TestPeekRefreshesTTL(t *testing.T) {
+	clock := newFakeClock()
+	cache, _ := NewLRUWithOptions(
+		WithCapacity[int, string](2),
+		WithDefaultTTL[int, string](10*time.Second),
+		WithClock[int, string](clock),
+		WithPeekRefreshesTTL[int, string](true),
+	)
+	cache.Put(1, "one")
+	cache.Put(2, "two")
+
+	clock.Advance(8 * time.Second)
+	if _, ok := cache.Peek(1); !ok {
+		t.Fatal("expected key 1 to be present")
+	}
+	clock.Advance(8 * time.Second)
+	if _, ok := cache.Peek(1); !ok {
+		t.Error("expected Peek to have extended key 1's TTL")
+	}
+	if _, ok := cache.Peek(2); ok {
+		t.Error("expected key 2 to expire")
+	}
+
+	cache.Put(3, "three")
+	cache.Put(4, "four")
+	if cache.Contains(1) {
+		t.Error("expected Peek not to protect key 1 from eviction")
+	}
+}
+
+// TestPeekRefreshesTTLDefault verifies Peek does not extend expiry by default.
+func TestPeekRefreshesTTLDefault(t *testing.T) {
+	clock := newFakeClock()
+	cache, _ := NewLRUWithOptions(
+		WithCapacity[int, string](2),
+		WithDefaultTTL[int, string](10*time.Second),
+		WithClock[int, string](clock),
+	)
+	cache.Put(1, "one")
+	clock.Advance(8 * time.Second)
+	cache.Peek(1)
+	clock.Advance(8 * time.Second)
+	if _, ok := cache.Peek(1); ok {
+		t.Error("expected key 1 to expire")
+	}
+}
+
+// TestExpiredKeys verifies exactly the past-deadline keys are listed and
+// nothing is removed.
+func TestExpiredKeys(t *testing.T) {
+	clock := newFakeClock()
+	cache, _ := NewLRUWithOptions(WithCapacity[int, string](5), WithClock[int, string](clock))
+	cache.PutWithTTL(1, "one", time.Second)
+	cache.PutWithTTL(2, "two", 3*time.Second)
+	cache.Put(3, "three")
+	cache.PutWithTTL(4, "four", time.Second)
+
+	if keys := cache.ExpiredKeys(); keys != nil {
+		t.Errorf("expected no expired keys, got %v", keys)
+	}
+
+	clock.Advance(2 * time.Second)
+	keys := cache.ExpiredKeys()
+	if len(keys) != 2 || keys[0] != 1 || keys[1] != 4 {
+		t.Errorf("expected [1 4], got %v", keys)
+	}
+	if cache.Len() != 4 {
+		t.Errorf("expected ExpiredKeys not to remove entries, got len %d", cache.Len())
+	}
+}
+
+// TestLiveLen verifies expired entries are excluded from LiveLen but not Len.
+func TestLiveLen(t *testing.T) {
+	clock := newFakeClock()
+	cache, _ := NewLRUWithOptions(WithCapacity[int, string](5), WithClock[int, string](clock))
+	cache.PutWithTTL(1, "one", time.Second)
+	cache.PutWithTTL(2, "two", time.Second)
+	cache.PutWithTTL(3, "three", time.Hour)
+	cache.Put(4, "four")
+
+	if cache.LiveLen() != 4 {
+		t.Errorf("expected 4 live entries, got %d", cache.LiveLen())
+	}
+	clock.Advance(time.Minute)
+	if cache.LiveLen() != 2 || cache.Len() != 4 {
+		t.Errorf("expected LiveLen 2 and Len 4, got %d and %d", cache.LiveLen(), cache.Len())
+	}
+}
@@ -0,0 +1,171 @@
+package lru
+
+import "container/list"
+
+// Default 2Q queue sizes as fractions of capacity, from the original paper.
+const (
+	DefaultTwoQueueInRatio  = 0.25
+	DefaultTwoQueueOutRatio = 0.50
+)
+
+// TwoQueuePolicy implements the scan-resistant 2Q algorithm. New entries
+// enter a FIFO probation queue (A1in). Entries evicted from A1in are
+// remembered by key in a ghost queue (A1out); if such a key is inserted
+// again it goes straight to the hot LRU queue (Am). A one-off scan therefore
+// only churns A1in and leaves the hot working set in Am alone. Entries
+// removed for other reasons, such as Remove or expiry, are not remembered.
+//
+// The cache list is kept in recency order.
+type TwoQueuePolicy[K comparable, V any] struct {
+	kin   int // target size of A1in
+	kout  int // maximum size of A1out
+	a1in  *list.List
+	am    *list.List
+	nodes map[*list.Element]*twoQueueNode
+
+	a1out *list.List // holds K, most recently evicted first
+	ghost map[K]*list.Element
+}
+
+// twoQueueNode locates a cache element within A1in or Am.
+type twoQueueNode struct {
+	queue *list.List
+	item  *list.Element // holds the cache *list.Element
+}
+
+// NewTwoQueuePolicy creates a 2Q policy for a cache of the given capacity.
+// inRatio and outRatio size A1in and A1out as fractions of capacity; values
+// <= 0 select DefaultTwoQueueInRatio and DefaultTwoQueueOutRatio.
+func NewTwoQueuePolicy[K comparable, V any](capacity int, inRatio, outRatio float64) *TwoQueuePolicy[K, V] {
+	if inRatio <= 0 {
+		inRatio = DefaultTwoQueueInRatio
+	}
+	if outRatio <= 0 {
+		outRatio = DefaultTwoQueueOutRatio
+	}
+	return &TwoQueuePolicy[K, V]{
+		kin:   int(float64(capacity) * inRatio),
+		kout:  int(float64(capacity) * outRatio),
+		a1in:  list.New(),
+		am:    list.New(),
+		nodes: make(map[*list.Element]*twoQueueNode),
+		a1out: list.New(),
+		ghost: make(map[K]*list.Element),
+	}
+}
+
+// OnInsert places el in Am if its key was recently evicted from A1in,
+// otherwise in A1in.
+func (p *TwoQueuePolicy[K, V]) OnInsert(l *list.List, el *list.Element) {
+	key := ElementKey[K, V](el)
+	queue := p.a1in
+	if g, ok := p.ghost[key]; ok {
+		p.a1out.Remove(g)
+		delete(p.ghost, key)
+		queue = p.am
+	}
+	p.nodes[el] = &twoQueueNode{queue: queue, item: queue.PushFront(el)}
+}
+
+// OnAccess moves el to the front of l and, if it is hot, of Am. Entries in
+// A1in are not promoted by access.
+func (p *TwoQueuePolicy[K, V]) OnAccess(l *list.List, el *list.Element) {
+	l.MoveToFront(el)
+	if n, ok := p.nodes[el]; ok && n.queue == p.am {
+		p.am.MoveToFront(n.item)
+	}
+}
+
+// OnRemove forgets el.
+func (p *TwoQueuePolicy[K, V]) OnRemove(el *list.Element) {
+	p.forget(el)
+}
+
+// OnEvict implements PolicyEvicter. It forgets el and, if el was in A1in,
+// remembers its key in A1out.
+func (p *TwoQueuePolicy[K, V]) OnEvict(el *list.Element) {
+	n := p.forget(el)
+	if n == nil || n.queue != p.a1in || p.kout <= 0 {
+		return
+	}
+	key := ElementKey[K, V](el)
+	if g, ok := p.ghost[key]; ok {
+		p.a1out.Remove(g)
+	}
+	p.ghost[key] = p.a1out.PushFront(key)
+	for p.a1out.Len() > p.kout {
+		delete(p.ghost, p.a1out.Remove(p.a1out.Back()).(K))
+	}
+}
+
+// forget removes el from its queue and returns the node it had, or nil if
+// el was not tracked.
+func (p *TwoQueuePolicy[K, V]) forget(el *list.Element) *twoQueueNode {
+	n, ok := p.nodes[el]
+	if !ok {
+		return nil
+	}
+	n.queue.Remove(n.item)
+	delete(p.nodes, el)
+	return n
+}
+
+// Victim walks A1in from oldest to newest and then Am from least to most
+// recently used while A1in is over its target size, otherwise Am first.
+func (p *TwoQueuePolicy[K, V]) Victim(l *list.List, after *list.Element) *list.Element {
+	first, second := p.am, p.a1in
+	if p.a1in.Len() > p.kin || p.am.Len() == 0 {
+		first, second = p.a1in, p.am
+	}
+	var item *list.Element
+	if after == nil {
+		item = first.Back()
+	} else if n, ok := p.nodes[after]; ok {
+		item = n.item.Prev()
+		if item == nil && n.queue == first {
+			item = second.Back()
+		}
+	}
+	if item == nil && after == nil {
+		item = second.Back()
+	}
+	if item == nil {
+		return nil
+	}
+	return item.Value.(*list.Element)
+}
+
+// ClonePolicy returns a 2Q policy with the same queue sizes holding the
+// copied elements in the same queues and order, and the same ghost keys.
+func (p *TwoQueuePolicy[K, V]) ClonePolicy(elems map[*list.Element]*list.Element) Policy {
+	cp := &TwoQueuePolicy[K, V]{
+		kin:   p.kin,
+		kout:  p.kout,
+		a1in:  list.New(),
+		am:    list.New(),
+		nodes: make(map[*list.Element]*twoQueueNode, len(p.nodes)),
+		a1out: list.New(),
+		ghost: make(map[K]*list.Element, len(p.ghost)),
+	}
+	for _, q := range [][2]*list.List{{p.a1in, cp.a1in}, {p.am, cp.am}} {
+		for item := q[0].Front(); item != nil; item = item.Next() {
+			if el, ok := elems[item.Value.(*list.Element)]; ok {
+				cp.nodes[el] = &twoQueueNode{queue: q[1], item: q[1].PushBack(el)}
+			}
+		}
+	}
+	for g := p.a1out.Front(); g != nil; g = g.Next() {
+		key := g.Value.(K)
+		cp.ghost[key] = cp.a1out.PushBack(key)
+	}
+	return cp
+}
+
+// Reset forgets all elements and ghost keys.
+func (p *TwoQueuePolicy[K, V]) Reset() {
+	p.a1in.Init()
+	p.am.Init()
+	p.nodes = make(map[*list.Element]*twoQueueNode)
+	p.a1out.Init()
+	p.ghost = make(map[K]*list.Element)
+}
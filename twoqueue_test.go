@@ -0,0 +1,103 @@
+package lru
+
+import "testing"
+
+// scanWorkload repeatedly reads a small hot working set interleaved with
+// scans of never-repeated keys, filling misses with Put, and returns the
+// hits on the hot set.
+func scanWorkload(cache *LRU[int, int]) int {
+	const hot, scan, rounds = 20, 40, 20
+	hits := 0
+	cold := 1000
+	for r := 0; r < rounds; r++ {
+		for k := 0; k < hot; k++ {
+			if _, ok := cache.Get(k); ok {
+				hits++
+			} else {
+				cache.Put(k, k)
+			}
+		}
+		for i := 0; i < scan; i++ {
+			cache.Put(cold, cold)
+			cold++
+		}
+	}
+	return hits
+}
+
+// TestTwoQueueScanResistance verifies 2Q keeps the hot set through scans
+// that flush a plain LRU.
+func TestTwoQueueScanResistance(t *testing.T) {
+	const capacity = 50
+	lruCache, _ := NewLRU[int, int](capacity)
+	twoQ, err := NewLRUWithOptions(
+		WithCapacity[int, int](capacity),
+		WithPolicy[int, int](NewTwoQueuePolicy[int, int](capacity, 0, 0)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lruHits := scanWorkload(lruCache)
+	twoQHits := scanWorkload(twoQ)
+
+	if twoQHits <= lruHits {
+		t.Errorf("expected 2Q to beat LRU, got %d vs %d hits", twoQHits, lruHits)
+	}
+	if twoQ.Len() > capacity {
+		t.Errorf("expected at most %d entries, got %d", capacity, twoQ.Len())
+	}
+}
+
+// TestTwoQueuePromotion verifies keys re-inserted from A1out become hot.
+func TestTwoQueuePromotion(t *testing.T) {
+	p := NewTwoQueuePolicy[int, string](4, 0.5, 1)
+	cache, _ := NewLRUWithOptions(WithCapacity[int, string](4), WithPolicy[int, string](p))
+
+	cache.Put(1, "one")
+	cache.Put(2, "two")
+	cache.Put(3, "three")
+	cache.Put(4, "four")
+	cache.Put(5, "five") // A1in over target: evicts 1 into A1out
+
+	if cache.Contains(1) {
+		t.Fatalf("expected key 1 to be evicted")
+	}
+	if _, ok := p.ghost[1]; !ok {
+		t.Fatalf("expected key 1 to be remembered in A1out")
+	}
+
+	cache.Put(1, "one") // re-inserted straight into Am
+	if n := p.nodes[cache.idx[1]]; n == nil || n.queue != p.am {
+		t.Errorf("expected key 1 to be promoted to Am")
+	}
+
+	cache.Put(6, "six")
+	cache.Put(7, "seven")
+	if !cache.Contains(1) {
+		t.Errorf("expected hot key 1 to survive new inserts")
+	}
+
+	cache.Clear()
+	if len(p.nodes) != 0 || len(p.ghost) != 0 {
+		t.Errorf("expected Clear to reset 2Q state")
+	}
+}
+
+// TestTwoQueueRemoveNotGhosted verifies a key removed with Remove is not
+// remembered in A1out, so storing it again puts it on probation in A1in.
+func TestTwoQueueRemoveNotGhosted(t *testing.T) {
+	p := NewTwoQueuePolicy[int, string](4, 0.5, 1)
+	cache, _ := NewLRUWithOptions(WithCapacity[int, string](4), WithPolicy[int, string](p))
+
+	cache.Put(1, "one")
+	cache.Remove(1)
+	if _, ok := p.ghost[1]; ok {
+		t.Fatalf("expected removed key 1 not to be remembered in A1out")
+	}
+
+	cache.Put(1, "one")
+	if n := p.nodes[cache.idx[1]]; n == nil || n.queue != p.a1in {
+		t.Errorf("expected key 1 to be stored in A1in")
+	}
+}
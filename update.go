@@ -0,0 +1,58 @@
+package lru
+
+// Number is the set of numeric types supported by Increment.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Update stores the result of f for key and promotes the entry. f is
+// called with the current value and true if the key is present, or the
+// zero value and false if not, in which case the result is inserted. An
+// existing entry keeps its expiry; a new one gets the default TTL. Returns
+// the stored value. f runs with the write lock held, so it must be fast and
+// must not call back into the cache.
+func (c *LRU[K, V]) Update(key K, f func(old V, ok bool) V) V {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.update(key, f)
+}
+
+// Increment adds delta to the value for key in c, treating a missing key as
+// zero, promotes the entry and returns the new value. The read, add and
+// store happen under one lock, so concurrent increments are never lost. An
+// existing entry keeps its expiry; a new one gets the default TTL. It is a
+// function rather than a method because it only applies to numeric values.
+func Increment[K comparable, V Number](c *LRU[K, V], key K, delta V) V {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.update(key, func(old V, _ bool) V {
+		return old + delta
+	})
+}
+
+// update stores f applied to the current value for key, keeping the expiry
+// of an existing entry, and returns the stored value. Caller must hold the
+// write lock.
+func (c *LRU[K, V]) update(key K, f func(old V, ok bool) V) V {
+	if el, ok := c.idx[key]; ok {
+		kv := el.Value.(*entry[K, V])
+		if c.live(kv) {
+			val := f(kv.val, true)
+			expires := kv.expires
+			c.put(key, val, kv.ttl, c.replacementCost(kv, val))
+			if el, ok := c.idx[key]; ok {
+				el.Value.(*entry[K, V]).expires = expires
+			}
+			return c.readStored(key, val)
+		}
+		if c.expired(kv) {
+			c.expire(el)
+		}
+	}
+	var zero V
+	val := f(zero, false)
+	c.put(key, val, c.ttl, c.sizeOf(key, val))
+	return c.readStored(key, val)
+}
@@ -0,0 +1,109 @@
+package lru
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestIncrement verifies missing keys start at zero and the result is
+// returned and stored.
+func TestIncrement(t *testing.T) {
+	cache, _ := NewLRU[string, int](2)
+	if v := Increment(cache, "a", 5); v != 5 {
+		t.Errorf("expected 5, got %d", v)
+	}
+	if v := Increment(cache, "a", -2); v != 3 {
+		t.Errorf("expected 3, got %d", v)
+	}
+	if v, _ := cache.Peek("a"); v != 3 {
+		t.Errorf("expected stored 3, got %d", v)
+	}
+
+	floats, _ := NewLRU[string, float64](2)
+	if v := Increment(floats, "x", 0.5); v != 0.5 {
+		t.Errorf("expected 0.5, got %v", v)
+	}
+}
+
+// TestIncrementConcurrent verifies concurrent increments are not lost.
+func TestIncrementConcurrent(t *testing.T) {
+	cache, _ := NewLRU[string, int64](2)
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				Increment(cache, "hits", 1)
+			}
+		}()
+	}
+	wg.Wait()
+	if v, _ := cache.Peek("hits"); v != 5000 {
+		t.Errorf("expected 5000, got %d", v)
+	}
+}
+
+// TestIncrementKeepsExpiry verifies incrementing does not extend the TTL.
+func TestIncrementKeepsExpiry(t *testing.T) {
+	clock := newFakeClock()
+	cache, _ := NewLRUWithOptions(
+		WithCapacity[string, int](2),
+		WithDefaultTTL[string, int](time.Minute),
+		WithClock[string, int](clock),
+	)
+	Increment(cache, "a", 1)
+	clock.Advance(40 * time.Second)
+	Increment(cache, "a", 1)
+	clock.Advance(40 * time.Second)
+	if v := Increment(cache, "a", 1); v != 1 {
+		t.Errorf("expected the counter to restart after expiry, got %d", v)
+	}
+}
+
+// TestUpdate verifies f sees existing and missing keys and its result is
+// stored and promoted.
+func TestUpdate(t *testing.T) {
+	cache, _ := NewLRU[string, []string](2)
+	appendTag := func(tag string) func([]string, bool) []string {
+		return func(old []string, ok bool) []string {
+			return append(old, tag)
+		}
+	}
+
+	var sawMissing bool
+	cache.Update("a", func(old []string, ok bool) []string {
+		sawMissing = !ok
+		return []string{"first"}
+	})
+	if !sawMissing {
+		t.Error("expected f to be told the key was missing")
+	}
+	cache.Put("b", nil)
+
+	got := cache.Update("a", appendTag("second"))
+	if len(got) != 2 || got[1] != "second" {
+		t.Errorf("expected [first second], got %v", got)
+	}
+
+	cache.Put("c", nil) // "a" was promoted, so "b" is evicted
+	if !cache.Contains("a") || cache.Contains("b") {
+		t.Errorf("expected Update to promote a, got %v", cache.Keys())
+	}
+}
+
+// TestUpdateKeepsExplicitCost verifies Update and Increment keep a cost
+// given to PutWithCost when there is no sizer.
+func TestUpdateKeepsExplicitCost(t *testing.T) {
+	cache, _ := NewLRUWithMaxCost[string, int](10, 100, nil)
+	cache.PutWithCost("a", 1, 40)
+	cache.Update("a", func(old int, ok bool) int { return old + 1 })
+	if cache.Cost() != 40 {
+		t.Errorf("expected cost 40 after Update, got %d", cache.Cost())
+	}
+	Increment(cache, "a", 1)
+	if cache.Cost() != 40 {
+		t.Errorf("expected cost 40 after Increment, got %d", cache.Cost())
+	}
+}
@@ -0,0 +1,53 @@
+package lru
+
+// KeysForValue returns the keys of the live entries holding v, in no
+// particular order. The cache must have been created with WithValueIndex;
+// otherwise KeysForValue returns nil.
+func (c *LRU[K, V]) KeysForValue(v V) []K {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.values == nil {
+		return nil
+	}
+	var keys []K
+	for key := range c.values[v] {
+		if c.live(c.idx[key].Value.(*entry[K, V])) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// setValue replaces the value of kv, keeping the value index current.
+// Caller must hold the write lock.
+func (c *LRU[K, V]) setValue(kv *entry[K, V], val V) {
+	c.unindexValue(kv.key, kv.val)
+	kv.val = val
+	kv.version++
+	c.indexValue(kv.key, val)
+}
+
+// indexValue records that key holds val. Caller must hold the write lock.
+func (c *LRU[K, V]) indexValue(key K, val V) {
+	if c.values == nil {
+		return
+	}
+	keys := c.values[val]
+	if keys == nil {
+		keys = make(map[K]struct{})
+		c.values[val] = keys
+	}
+	keys[key] = struct{}{}
+}
+
+// unindexValue forgets that key holds val. Caller must hold the write lock.
+func (c *LRU[K, V]) unindexValue(key K, val V) {
+	if c.values == nil {
+		return
+	}
+	keys := c.values[val]
+	delete(keys, key)
+	if len(keys) == 0 {
+		delete(c.values, val)
+	}
+}
@@ -0,0 +1,62 @@
+package lru
+
+import (
+	"sort"
+	"testing"
+)
+
+// sortedKeysForValue returns KeysForValue(v) sorted.
+func sortedKeysForValue(c *LRU[string, int], v int) []string {
+	keys := c.KeysForValue(v)
+	sort.Strings(keys)
+	return keys
+}
+
+// TestKeysForValue verifies duplicate values map to all their keys and the
+// index follows overwrites, removals and evictions.
+func TestKeysForValue(t *testing.T) {
+	cache, _ := NewLRUWithOptions(WithCapacity[string, int](3), WithValueIndex[string, int]())
+	cache.Put("a", 1)
+	cache.Put("b", 1)
+	cache.Put("c", 2)
+
+	if keys := sortedKeysForValue(cache, 1); len(keys) != 2 || keys[0] != "a" || keys[1] != "b" {
+		t.Errorf("expected [a b], got %v", keys)
+	}
+
+	cache.Put("a", 2)
+	if keys := sortedKeysForValue(cache, 1); len(keys) != 1 || keys[0] != "b" {
+		t.Errorf("expected [b] after overwrite, got %v", keys)
+	}
+	if keys := sortedKeysForValue(cache, 2); len(keys) != 2 || keys[0] != "a" || keys[1] != "c" {
+		t.Errorf("expected [a c] after overwrite, got %v", keys)
+	}
+
+	cache.Remove("c")
+	if keys := sortedKeysForValue(cache, 2); len(keys) != 1 || keys[0] != "a" {
+		t.Errorf("expected [a] after removal, got %v", keys)
+	}
+
+	cache.Put("d", 3)
+	cache.Put("e", 3) // evicts "b"
+	if keys := cache.KeysForValue(1); len(keys) != 0 {
+		t.Errorf("expected no keys after eviction, got %v", keys)
+	}
+	if len(cache.values) != 2 {
+		t.Errorf("expected empty value sets to be dropped, got %v", cache.values)
+	}
+
+	cache.Clear()
+	if keys := cache.KeysForValue(3); len(keys) != 0 {
+		t.Errorf("expected no keys after Clear, got %v", keys)
+	}
+}
+
+// TestKeysForValueDisabled verifies KeysForValue returns nil without the index.
+func TestKeysForValueDisabled(t *testing.T) {
+	cache, _ := NewLRU[string, int](2)
+	cache.Put("a", 1)
+	if keys := cache.KeysForValue(1); keys != nil {
+		t.Errorf("expected nil, got %v", keys)
+	}
+}